@@ -0,0 +1,20 @@
+// Package data holds the files bundled into the binary.
+package data
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed scripts/*.json
+var scripts embed.FS
+
+// Scripts returns the bundled scripts, rooted at the scripts directory.
+func Scripts() fs.FS {
+	sub, err := fs.Sub(scripts, "scripts")
+	if err != nil {
+		// The directory is embedded at build time, so this cannot happen
+		panic("data: " + err.Error())
+	}
+	return sub
+}
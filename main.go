@@ -1,15 +1,28 @@
 package main
 
 import (
+	"clocktower/data"
+	"clocktower/model"
 	"clocktower/tui"
+	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 func main() {
-	m := tui.NewMainModel()
+	scriptsFlag := flag.String("scripts", "", "extra script directories, separated by '"+string(os.PathListSeparator)+"'")
+	undoDepth := flag.Int("undo-depth", model.DefaultUndoDepth, "number of undo steps kept, including in save files")
+	flag.Parse()
+	model.UndoDepth = *undoDepth
+
+	dirs := []string{model.UserScriptsDir()}
+	dirs = append(dirs, filepath.SplitList(*scriptsFlag)...)
+	scripts := model.DiscoverScripts(data.Scripts(), dirs)
+
+	m := tui.NewMainModel(scripts)
 	p := tea.NewProgram(m, tea.WithAltScreen())
 
 	if _, err := p.Run(); err != nil {
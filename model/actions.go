@@ -0,0 +1,281 @@
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Logic: Command Layer
+//
+// Every storyteller action goes through Do, so each one can be undone as a
+// whole, and records a command event (see act) so the game can be rebuilt.
+// Setup helpers such as SetDrunkIdentity run before the game starts and are
+// not undoable.
+
+// Do runs one action. The state is snapshotted first and pushed onto the
+// undo history if the action changed anything; a failed action is rolled
+// back. The game is saved afterwards. Nested calls just run.
+func (g *Game) Do(action func() error) error {
+	if g.acting {
+		return action()
+	}
+	g.acting = true
+	defer func() { g.acting = false }()
+
+	if g.replaying {
+		return action()
+	}
+	g.eventTime = time.Now()
+
+	before := g.captureSnapshot()
+	if err := action(); err != nil {
+		g.restoreSnapshot(before)
+		return err
+	}
+	if snapshotsEqual(before, g.captureSnapshot()) {
+		return nil
+	}
+
+	g.History.Push(before, UndoDepth)
+	g.Future = UndoStack{}
+	g.SaveState()
+	return nil
+}
+
+func snapshotsEqual(a, b snapshotFields) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range a {
+		if !bytes.Equal(value, b[key]) {
+			return false
+		}
+	}
+	return true
+}
+
+// AdvancePhase moves the game on: setup and night lead to day, and the end of
+// the day resolves the execution and starts the next night.
+func (g *Game) AdvancePhase() error {
+	return g.act(Event{Kind: EventPhase, Tag: "Game"}, func(e *Event) error {
+		if g.Phase == PhaseDay {
+			g.endDay()
+			g.Phase = PhaseNight
+			g.Turn++
+		} else {
+			g.Phase = PhaseDay
+		}
+		g.expireEffects(g.Phase)
+		g.expireReminders(g.Phase)
+		e.Message = fmt.Sprintf("%s %d begins", g.Phase, g.Turn)
+		return nil
+	})
+}
+
+func (g *Game) SetGhostVote(idx int, used bool) error {
+	if idx < 0 || idx >= len(g.Players) {
+		return fmt.Errorf("invalid player index")
+	}
+	p := g.Players[idx]
+	return g.act(Event{Kind: EventGhostVote, Tag: "Storyteller", Targets: []int{p.ID}}, func(e *Event) error {
+		p.UsedGhostVote = used
+		e.Result = "restored"
+		if used {
+			e.Result = "used"
+		}
+		e.Message = fmt.Sprintf("%s's ghost vote marked %s", p.Name, e.Result)
+		return nil
+	})
+}
+
+// SetRegistration makes a player register as another character type ("" to
+// clear).
+func (g *Game) SetRegistration(idx int, override string) error {
+	if idx < 0 || idx >= len(g.Players) {
+		return fmt.Errorf("invalid player index")
+	}
+	p := g.Players[idx]
+	return g.act(Event{Kind: EventRegistration, Tag: "Storyteller", Targets: []int{p.ID}, Result: override}, func(e *Event) error {
+		p.RegistrationOverride = override
+		e.Message = fmt.Sprintf("%s registers as a %s", p.Name, override)
+		if override == "" {
+			e.Message = fmt.Sprintf("%s registers as their own character", p.Name)
+		}
+		return nil
+	})
+}
+
+// SetRedHerring picks the good player the Fortune Teller sees as a Demon.
+func (g *Game) SetRedHerring(idx int) error {
+	if idx < 0 || idx >= len(g.Players) {
+		return fmt.Errorf("invalid player index")
+	}
+	target := g.Players[idx]
+	return g.act(Event{Kind: EventRedHerring, Tag: "Setup", Targets: []int{target.ID}}, func(e *Event) error {
+		for _, p := range g.Players {
+			p.IsRedHerring = false
+		}
+		target.IsRedHerring = true
+		g.placeResolverReminder("Fortune Teller", target)
+		e.Message = fmt.Sprintf("Fortune Teller Red Herring set to %s", target.Name)
+		return nil
+	})
+}
+
+// Nominate opens a vote. If nobody is able to vote it closes straight away.
+func (g *Game) Nominate(nominatorID, nomineeID int) error {
+	return g.Do(func() error {
+		nomination := Event{Kind: EventNomination, Tag: "Day", Actor: nominatorID, Targets: []int{nomineeID}}
+		err := g.act(nomination, func(e *Event) error {
+			if err := g.nominate(nominatorID, nomineeID); err != nil {
+				return err
+			}
+			e.Message = fmt.Sprintf("%s nominated %s",
+				g.GetPlayerByID(nominatorID).Name, g.GetPlayerByID(nomineeID).Name)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return g.closeIfEveryoneVoted()
+	})
+}
+
+// CastVote records the next voter's vote, closing the nomination after the
+// last one.
+func (g *Game) CastVote(raised bool) error {
+	return g.Do(func() error {
+		err := g.act(Event{Kind: EventVote, Tag: "Day"}, func(e *Event) error {
+			nom := g.CurrentNomination()
+			vote, err := g.castVote(raised)
+			if err != nil {
+				return err
+			}
+			voter := g.GetPlayerByID(vote.PlayerID)
+			nominee := g.GetPlayerByID(nom.NomineeID)
+			e.Actor = voter.ID
+			e.Targets = []int{nominee.ID}
+			switch {
+			case vote.GhostVote:
+				e.Result = "ghost"
+				e.Message = fmt.Sprintf("%s voted for %s (ghost vote)", voter.Name, nominee.Name)
+			case raised:
+				e.Result = "yes"
+				e.Message = fmt.Sprintf("%s voted for %s", voter.Name, nominee.Name)
+			default:
+				e.Result = "no"
+				e.Message = fmt.Sprintf("%s did not vote for %s", voter.Name, nominee.Name)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return g.closeIfEveryoneVoted()
+	})
+}
+
+// CancelNomination withdraws the open nomination, e.g. one made by mistake.
+func (g *Game) CancelNomination() error {
+	return g.act(Event{Kind: EventNominationCancel, Tag: "Day"}, func(e *Event) error {
+		nom, err := g.cancelNomination()
+		if err != nil {
+			return err
+		}
+		e.Actor = nom.NominatorID
+		e.Targets = []int{nom.NomineeID}
+		e.Message = fmt.Sprintf("%s's nomination of %s was withdrawn",
+			g.GetPlayerByID(nom.NominatorID).Name, g.GetPlayerByID(nom.NomineeID).Name)
+		return nil
+	})
+}
+
+func (g *Game) closeIfEveryoneVoted() error {
+	if g.NextVoter() != nil {
+		return nil
+	}
+	return g.closeNomination()
+}
+
+// Night resolvers: each one is a single action and logs its result.
+
+// nightAction records a night resolver's result as a command event.
+func (g *Game) nightAction(e Event, resolve func() string) string {
+	e.Tag = "Night"
+	if actor := g.GetPlayerByID(e.Actor); actor != nil {
+		e.Malfunction = actor.IsMalfunctioning()
+	}
+	var msg string
+	g.act(e, func(e *Event) error {
+		msg = resolve()
+		e.Message = msg
+		return nil
+	})
+	return msg
+}
+
+func playerID(p *Player) int {
+	if p == nil {
+		return 0
+	}
+	return p.ID
+}
+
+func (g *Game) ResolveNightAction(actorName string, target *Player) string {
+	e := Event{Kind: EventNightAction, Actor: playerID(g.FindActor(actorName)), Role: actorName, Targets: []int{playerID(target)}}
+	return g.nightAction(e, func() string { return g.resolveNightAction(actorName, target) })
+}
+
+func (g *Game) ResolveInfoAction(actorName string, p1, p2 *Player, roleName string) string {
+	e := Event{Kind: EventInfo, Actor: playerID(g.FindActor(actorName)), Role: actorName,
+		Targets: []int{playerID(p1), playerID(p2)}, Result: roleName}
+	return g.nightAction(e, func() string { return g.resolveInfoAction(actorName, p1, p2, roleName) })
+}
+
+func (g *Game) ResolveFortuneTeller(actor *Player, p1, p2 *Player) string {
+	result := "NO"
+	if g.IsDemonOrRedHerring(p1) || g.IsDemonOrRedHerring(p2) {
+		result = "YES"
+	}
+	e := Event{Kind: EventFortuneTeller, Actor: playerID(actor), Role: "Fortune Teller",
+		Targets: []int{playerID(p1), playerID(p2)}, Result: result}
+	return g.nightAction(e, func() string { return g.resolveFortuneTeller(actor, p1, p2) })
+}
+
+// ResolveChef logs the number the storyteller showed the Chef, which may be
+// false when the Chef is drunk or poisoned.
+func (g *Game) ResolveChef(actor *Player, given int) string {
+	e := Event{Kind: EventChef, Actor: playerID(actor), Role: "Chef", Result: strconv.Itoa(given)}
+	return g.nightAction(e, func() string { return g.resolveChef(given) })
+}
+
+// ResolveUndertaker logs the character shown to the Undertaker for the player
+// executed today.
+func (g *Game) ResolveUndertaker(actor, executed *Player, shown string) string {
+	e := Event{Kind: EventCharacterInfo, Actor: playerID(actor), Role: "Undertaker", Targets: []int{playerID(executed)}, Result: shown}
+	return g.nightAction(e, func() string { return g.resolveUndertaker(executed, shown) })
+}
+
+// ResolveRavenkeeper logs the character shown to the Ravenkeeper for the
+// player they chose after dying tonight.
+func (g *Game) ResolveRavenkeeper(actor, target *Player, shown string) string {
+	e := Event{Kind: EventCharacterInfo, Actor: playerID(actor), Role: "Ravenkeeper", Targets: []int{playerID(target)}, Result: shown}
+	return g.nightAction(e, func() string { return g.resolveRavenkeeper(target, shown) })
+}
+
+// StarPass is the storyteller's choice of who catches the star when the Imp
+// kills themself.
+func (g *Game) StarPass(demon, recipient *Player) string {
+	e := Event{Kind: EventStarPass, Actor: playerID(demon), Role: demon.Role.Name, Targets: []int{playerID(recipient)}}
+	return g.nightAction(e, func() string { return g.starPass(demon, recipient) })
+}
+
+func (g *Game) ResolveMinionInfo() string {
+	return g.nightAction(Event{Kind: EventEvilInfo, Role: MinionInfoStep}, g.resolveMinionInfo)
+}
+
+func (g *Game) ResolveDemonInfo() string {
+	return g.nightAction(Event{Kind: EventEvilInfo, Role: DemonInfoStep}, g.resolveDemonInfo)
+}
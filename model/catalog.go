@@ -0,0 +1,84 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+)
+
+// BundledOrigin marks scripts embedded in the binary.
+const BundledOrigin = "built-in"
+
+// ScriptSource is a script found during discovery. Scripts that fail to
+// read or parse are still listed with Err set, so the problem can be shown
+// when the script is picked.
+type ScriptSource struct {
+	Origin string // BundledOrigin or the directory the file was found in
+	File   string // File name within the origin
+	Script Script
+	Err    error
+}
+
+// Label describes the script for a selection menu.
+func (s ScriptSource) Label() string {
+	if s.Err != nil {
+		return fmt.Sprintf("%s (%s, invalid)", s.File, s.Origin)
+	}
+	label := s.Script.Name
+	if s.Script.Author != "" {
+		label += " by " + s.Script.Author
+	}
+	return fmt.Sprintf("%s (%s)", label, s.Origin)
+}
+
+// UserScriptsDir is where user scripts are looked up by default:
+// $XDG_CONFIG_HOME/clocktower/scripts (or the platform equivalent).
+// It returns "" when no config directory is known.
+func UserScriptsDir() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "clocktower", "scripts")
+}
+
+// DiscoverScripts lists the bundled scripts followed by the *.json files in
+// each directory, in order. Missing directories are skipped.
+func DiscoverScripts(bundled fs.FS, dirs []string) []ScriptSource {
+	sources := readScripts(bundled, BundledOrigin)
+	for _, dir := range dirs {
+		if dir == "" {
+			continue
+		}
+		if _, err := os.Stat(dir); errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		sources = append(sources, readScripts(os.DirFS(dir), dir)...)
+	}
+	return sources
+}
+
+func readScripts(fsys fs.FS, origin string) []ScriptSource {
+	files, err := fs.Glob(fsys, "*.json")
+	if err != nil {
+		return nil
+	}
+	sort.Strings(files)
+
+	sources := make([]ScriptSource, 0, len(files))
+	for _, file := range files {
+		source := ScriptSource{Origin: origin, File: path.Base(file)}
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			source.Err = fmt.Errorf("could not read %s: %w", file, err)
+		} else if source.Script, err = ParseScript(data); err != nil {
+			source.Err = fmt.Errorf("%s: %w", file, err)
+		}
+		sources = append(sources, source)
+	}
+	return sources
+}
@@ -0,0 +1,75 @@
+package model
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestDiscoverScripts(t *testing.T) {
+	bundled := fstest.MapFS{
+		"trouble_brewing.json": {Data: []byte(`["imp", "chef", {"id": "_meta", "name": "Trouble Brewing", "author": "TPI"}]`)},
+		"notes.md":             {Data: []byte("not a script")},
+	}
+	extra := filepath.Join("testdata", "discover", "extra")
+	more := filepath.Join("testdata", "discover", "more")
+
+	tests := []struct {
+		name string
+		dirs []string
+		want []string // Origin/File, with "!" when the script failed to load
+	}{
+		{"bundled only", nil, []string{"built-in/trouble_brewing.json"}},
+		{"directories in order", []string{more, extra}, []string{
+			"built-in/trouble_brewing.json",
+			more + "/own.json",
+			"!" + extra + "/broken.json",
+			extra + "/tiny.json",
+		}},
+		{"empty and missing directories are skipped", []string{"", filepath.Join("testdata", "discover", "missing"), more},
+			[]string{"built-in/trouble_brewing.json", more + "/own.json"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sources := DiscoverScripts(bundled, tt.dirs)
+			var got []string
+			for _, s := range sources {
+				entry := s.Origin + "/" + s.File
+				if s.Err != nil {
+					entry = "!" + entry
+				}
+				got = append(got, entry)
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("DiscoverScripts() =\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestScriptSourceLabel(t *testing.T) {
+	extra := filepath.Join("testdata", "discover", "extra")
+	more := filepath.Join("testdata", "discover", "more")
+	sources := DiscoverScripts(fstest.MapFS{}, []string{extra, more})
+	labels := make(map[string]string)
+	for _, s := range sources {
+		labels[s.File] = s.Label()
+	}
+
+	want := map[string]string{
+		"broken.json": "broken.json (" + extra + ", invalid)",
+		"tiny.json":   "Custom Script (" + extra + ")",
+		"own.json":    "Own by Me (" + more + ")",
+	}
+	for file, label := range want {
+		if labels[file] != label {
+			t.Errorf("label for %s = %q, want %q", file, labels[file], label)
+		}
+	}
+	for _, s := range sources {
+		if s.File == "broken.json" && !strings.Contains(s.Err.Error(), "broken.json: invalid script JSON") {
+			t.Errorf("broken.json error = %v", s.Err)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package model
+
+import "fmt"
+
+// Logic: Demon Succession
+
+// StarPassCandidates returns the living Minions who could catch the Demon.
+func (g *Game) StarPassCandidates() []*Player {
+	var candidates []*Player
+	for _, p := range g.Players {
+		if p != nil && p.IsAlive && p.Role.Type == Minion {
+			candidates = append(candidates, p)
+		}
+	}
+	return candidates
+}
+
+// DefaultStarPassRecipient picks the Scarlet Woman if she is alive, otherwise
+// the first living Minion in seat order. Returns nil if no Minion is alive.
+func (g *Game) DefaultStarPassRecipient() *Player {
+	candidates := g.StarPassCandidates()
+	for _, p := range candidates {
+		if p.Role.Name == "Scarlet Woman" {
+			return p
+		}
+	}
+	if len(candidates) > 0 {
+		return candidates[0]
+	}
+	return nil
+}
+
+// starPass kills the Demon who targeted themself and makes the recipient the
+// new Demon. The old role is kept in the recipient's role history. The
+// Scarlet Woman is not triggered, since the recipient already catches the
+// star.
+func (g *Game) starPass(demon, recipient *Player) string {
+	demonRole := demon.Role.Name
+	g.markDead(demon)
+	g.placeResolverReminder(demonRole, demon)
+
+	if recipient == nil || !recipient.IsAlive || recipient.Role.Type != Minion {
+		g.CheckWinConditions()
+		return fmt.Sprintf("%s killed themself with no Minion to catch the star", demonRole)
+	}
+
+	oldRole := recipient.Role.Name
+	if err := g.changeRole(g.IndexOfPlayer(recipient.ID), demonRole, "Star Pass"); err != nil {
+		g.CheckWinConditions()
+		return fmt.Sprintf("%s killed themself but the star pass failed: %v", demonRole, err)
+	}
+
+	g.CheckWinConditions()
+	return fmt.Sprintf("%s killed themself! %s (%s) is the new %s", demonRole, recipient.Name, oldRole, demonRole)
+}
@@ -0,0 +1,34 @@
+package model
+
+import "testing"
+
+func TestStarPassKillsTheDemon(t *testing.T) {
+	g := newTestGame(t, "Imp", "Poisoner", "Scarlet Woman", "Chef", "Empath", "Monk", "Soldier")
+	imp, sw, chef := g.Players[0], g.Players[2], g.Players[3]
+	if err := g.AdvancePhase(); err != nil {
+		t.Fatal(err)
+	}
+	// An effect with the Imp as its source, as a later Demon ability would add
+	g.addEffect(chef, EffectPoisoned, imp, "Imp", Permanent)
+
+	g.ResolveNightAction("Imp", imp)
+
+	if imp.IsAlive {
+		t.Fatal("Imp is still alive")
+	}
+	if imp.Death == nil {
+		t.Fatal("Imp has no death record")
+	}
+	if !g.DiedTonight(imp) {
+		t.Error("DiedTonight(Imp) = false")
+	}
+	if chef.IsPoisoned() {
+		t.Error("effect from the old Imp did not end")
+	}
+	if sw.Role.Name != "Imp" {
+		t.Errorf("Scarlet Woman is the %s, want Imp", sw.Role.Name)
+	}
+	if r, _ := g.resolverReminder("Imp"); len(imp.Reminders) != 1 || imp.Reminders[0] != r {
+		t.Errorf("old Imp reminders = %v, want [%v]", imp.Reminders, r)
+	}
+}
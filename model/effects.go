@@ -0,0 +1,120 @@
+package model
+
+import "fmt"
+
+type EffectKind string
+
+const (
+	EffectPoisoned  EffectKind = "poisoned"
+	EffectDrunk     EffectKind = "drunk"
+	EffectProtected EffectKind = "protected"
+)
+
+// Duration says when the engine removes an effect.
+type Duration string
+
+const (
+	UntilDawn Duration = "until_dawn" // Tonight only, e.g. the Monk
+	UntilDusk Duration = "until_dusk" // Until the next night begins, e.g. the Poisoner
+	Permanent Duration = "permanent"  // e.g. the Drunk
+)
+
+// Effect is a status on a player caused by a character's ability. Whatever
+// its duration, an effect with a source player ends as soon as that player
+// dies, since their ability stops working.
+type Effect struct {
+	Kind       EffectKind `json:"kind"`
+	SourceID   int        `json:"source_id,omitempty"` // Player whose ability caused it, 0 for none
+	SourceRole string     `json:"source_role,omitempty"`
+	Duration   Duration   `json:"duration"`
+	Turn       int        `json:"turn"` // Turn it started
+}
+
+func (e Effect) String() string {
+	var until string
+	switch e.Duration {
+	case UntilDawn:
+		until = "until dawn"
+	case UntilDusk:
+		until = "until dusk"
+	case Permanent:
+		until = "permanently"
+	}
+	if e.SourceRole == "" {
+		return fmt.Sprintf("%s %s", e.Kind, until)
+	}
+	return fmt.Sprintf("%s by the %s %s", e.Kind, e.SourceRole, until)
+}
+
+func (p *Player) HasEffect(kind EffectKind) bool {
+	for _, e := range p.Effects {
+		if e.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Player) IsPoisoned() bool  { return p.HasEffect(EffectPoisoned) }
+func (p *Player) IsDrunk() bool     { return p.HasEffect(EffectDrunk) }
+func (p *Player) IsProtected() bool { return p.HasEffect(EffectProtected) }
+
+// removeEffects drops the effects that match and returns them.
+func (p *Player) removeEffects(match func(Effect) bool) []Effect {
+	var removed []Effect
+	kept := p.Effects[:0]
+	for _, e := range p.Effects {
+		if match(e) {
+			removed = append(removed, e)
+		} else {
+			kept = append(kept, e)
+		}
+	}
+	if len(removed) > 0 {
+		p.Effects = kept
+	}
+	return removed
+}
+
+// addEffect applies an effect from a character's ability. source may be nil
+// for effects no player causes.
+func (g *Game) addEffect(target *Player, kind EffectKind, source *Player, sourceRole string, d Duration) {
+	e := Effect{Kind: kind, SourceRole: sourceRole, Duration: d, Turn: g.Turn}
+	if source != nil {
+		e.SourceID = source.ID
+	}
+	target.Effects = append(target.Effects, e)
+}
+
+// expireEffects removes the effects that end as the given phase begins.
+func (g *Game) expireEffects(phase Phase) {
+	ends := UntilDusk
+	if phase == PhaseDay {
+		ends = UntilDawn
+	}
+	for _, p := range g.Players {
+		p.removeEffects(func(e Effect) bool { return e.Duration == ends })
+	}
+}
+
+// endEffectsFrom removes every effect a player caused, along with the
+// reminder tokens their character placed for them.
+func (g *Game) endEffectsFrom(source *Player) {
+	for _, p := range g.Players {
+		ended := p.removeEffects(func(e Effect) bool { return e.SourceID == source.ID })
+		for _, e := range ended {
+			if r, ok := g.resolverReminder(e.SourceRole); ok {
+				p.removeReminder(r)
+			}
+		}
+	}
+}
+
+// setDrunkCharacter keeps the Drunk's permanent drunkenness in step with the
+// player's character.
+func (p *Player) setDrunkCharacter(turn int) {
+	p.removeEffects(func(e Effect) bool { return e.Kind == EffectDrunk && e.SourceRole == "Drunk" })
+	if p.Role.Name == "Drunk" {
+		p.Effects = append(p.Effects, Effect{Kind: EffectDrunk, SourceRole: "Drunk", Duration: Permanent, Turn: turn})
+	}
+}
@@ -0,0 +1,90 @@
+package model
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestEffectsExpire(t *testing.T) {
+	g := newTestGame(t, "Imp", "Poisoner", "Chef", "Empath", "Monk", "Drunk")
+	chef, empath, drunk := g.Players[2], g.Players[3], g.Players[5]
+	poisoned, _ := g.resolverReminder("Poisoner")
+	protected, _ := g.resolverReminder("Monk")
+
+	if err := g.AdvancePhase(); err != nil {
+		t.Fatal(err)
+	}
+	g.ResolveNightAction("Poisoner", chef)
+	g.ResolveNightAction("Monk", empath)
+
+	// Each phase the game moves into, and what should still be in place
+	steps := []struct {
+		phase     Phase
+		poisoned  bool
+		protected bool
+	}{
+		{PhaseNight, true, true},
+		{PhaseDay, true, false},    // Dawn ends the Monk's protection
+		{PhaseNight, false, false}, // Dusk ends the poison
+	}
+	for i, step := range steps {
+		if i > 0 {
+			if err := g.AdvancePhase(); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if g.Phase != step.phase {
+			t.Fatalf("step %d: phase %s, want %s", i, g.Phase, step.phase)
+		}
+		if chef.IsPoisoned() != step.poisoned || slices.Contains(chef.Reminders, poisoned) != step.poisoned {
+			t.Errorf("%s %d: Chef poisoned %v with reminders %v, want %v", g.Phase, g.Turn, chef.IsPoisoned(), chef.Reminders, step.poisoned)
+		}
+		if empath.IsProtected() != step.protected || slices.Contains(empath.Reminders, protected) != step.protected {
+			t.Errorf("%s %d: Empath protected %v with reminders %v, want %v", g.Phase, g.Turn, empath.IsProtected(), empath.Reminders, step.protected)
+		}
+		if !drunk.IsDrunk() {
+			t.Errorf("%s %d: the Drunk is no longer drunk", g.Phase, g.Turn)
+		}
+	}
+}
+
+func TestPoisonerDeathEndsPoison(t *testing.T) {
+	tests := []struct {
+		name string
+		kill func(g *Game, poisoner *Player)
+	}{
+		{"killed by the Imp", func(g *Game, poisoner *Player) {
+			g.ResolveNightAction("Imp", poisoner)
+		}},
+		{"marked dead the next day", func(g *Game, poisoner *Player) {
+			g.AdvancePhase()
+			g.SetPlayerAlive(g.IndexOfPlayer(poisoner.ID), false)
+		}},
+		{"marked dead at night", func(g *Game, poisoner *Player) {
+			g.SetPlayerAlive(g.IndexOfPlayer(poisoner.ID), false)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame(t, "Imp", "Poisoner", "Chef", "Empath", "Monk", "Soldier")
+			poisoner, chef := g.Players[1], g.Players[2]
+			poisoned, _ := g.resolverReminder("Poisoner")
+			g.AdvancePhase()
+			g.ResolveNightAction("Poisoner", chef)
+			if !chef.IsPoisoned() {
+				t.Fatal("Chef was not poisoned")
+			}
+
+			tt.kill(g, poisoner)
+			if poisoner.IsAlive {
+				t.Fatal("Poisoner is still alive")
+			}
+			if chef.IsPoisoned() {
+				t.Errorf("Chef is still poisoned: %v", chef.Effects)
+			}
+			if slices.Contains(chef.Reminders, poisoned) {
+				t.Errorf("Chef still has the %v reminder", poisoned)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type EventKind string
+
+// Commands: storyteller actions, replayed by Rebuild
+const (
+	EventPhase            EventKind = "phase"
+	EventLife             EventKind = "life"
+	EventSwap             EventKind = "swap"
+	EventRoleChange       EventKind = "role_change"
+	EventGhostVote        EventKind = "ghost_vote"
+	EventRegistration     EventKind = "registration"
+	EventRedHerring       EventKind = "red_herring"
+	EventBluffs           EventKind = "bluffs"
+	EventNomination       EventKind = "nomination"
+	EventVote             EventKind = "vote"
+	EventNominationCancel EventKind = "nomination_cancel"
+	EventNightAction      EventKind = "night_action"
+	EventInfo             EventKind = "info"
+	EventFortuneTeller    EventKind = "fortune_teller"
+	EventChef             EventKind = "chef"
+	EventCharacterInfo    EventKind = "character_info" // Undertaker, Ravenkeeper
+	EventStarPass         EventKind = "star_pass"
+	EventEvilInfo         EventKind = "evil_info"
+	EventReminderAdd      EventKind = "reminder_add"
+	EventReminderRemove   EventKind = "reminder_remove"
+)
+
+// Consequences: recorded by the game logic while a command runs
+const (
+	EventExecution  EventKind = "execution"
+	EventVoteSkip   EventKind = "vote_skip"
+	EventVoteResult EventKind = "vote_result"
+	EventGameOver   EventKind = "game_over"
+	EventGameResume EventKind = "game_resume" // A revive reopened a finished game
+	EventNote       EventKind = "note"        // Log lines from saves older than events
+)
+
+// Event is one entry in the game log. Command events record a storyteller
+// action with its inputs, so the game can be rebuilt by replaying them;
+// the other events describe what followed from it.
+type Event struct {
+	Kind        EventKind `json:"kind"`
+	Command     bool      `json:"command,omitempty"`
+	Tag         string    `json:"tag"` // Log prefix: Night, Day, Setup, Game, Storyteller
+	Message     string    `json:"message"`
+	Turn        int       `json:"turn"`
+	Phase       Phase     `json:"phase"`
+	Actor       int       `json:"actor,omitempty"`   // Player ID
+	Role        string    `json:"role,omitempty"`    // Character acting, or the character involved
+	Targets     []int     `json:"targets,omitempty"` // Player IDs
+	Result      string    `json:"result,omitempty"`  // e.g. YES/NO, the role shown, the vote
+	Malfunction bool      `json:"malfunction,omitempty"`
+	Time        time.Time `json:"time"`
+}
+
+// String renders the event as a log line.
+func (e Event) String() string {
+	return fmt.Sprintf("[%s] %s", e.Tag, e.Message)
+}
+
+// record appends an event stamped with the current turn and phase. Every
+// event of one action shares its time, so replaying reproduces it.
+func (g *Game) record(e Event, format string, args ...any) {
+	e.Message = fmt.Sprintf(format, args...)
+	e.Turn = g.Turn
+	e.Phase = g.Phase
+	e.Time = g.eventTime
+	g.Events = append(g.Events, e)
+}
+
+// act performs a storyteller action as one undoable step and records it as a
+// command event after its consequences. run fills in the event's details and
+// message. Actions must not call other actions; they share the unexported
+// logic instead, so each one records exactly one command.
+func (g *Game) act(e Event, run func(e *Event) error) error {
+	return g.Do(func() error {
+		if err := run(&e); err != nil {
+			return err
+		}
+		e.Command = true
+		g.record(e, "%s", e.Message)
+		return nil
+	})
+}
+
+// Begin marks the dealt setup as the starting point Rebuild replays from.
+func (g *Game) Begin() {
+	g.Initial = g.captureSnapshot()
+}
+
+// Rebuild recomputes the game state by replaying the command events from the
+// starting state. Consequences are regenerated, so the result matches the
+// saved state as long as the game logic has not changed.
+func (g *Game) Rebuild() error {
+	return g.rebuild(len(g.Events))
+}
+
+// rebuild replays the commands among the first n events.
+func (g *Game) rebuild(n int) error {
+	if g.Initial == nil {
+		return fmt.Errorf("game has no recorded starting state")
+	}
+	events := g.Events[:n]
+	if err := g.restoreSnapshot(g.Initial); err != nil {
+		return err
+	}
+
+	g.replaying = true
+	defer func() { g.replaying = false }()
+	for _, e := range events {
+		if !e.Command {
+			continue
+		}
+		g.eventTime = e.Time
+		if err := g.replay(e); err != nil {
+			return fmt.Errorf("replaying %q: %w", e.String(), err)
+		}
+	}
+	return nil
+}
+
+// replay runs the action a command event recorded.
+func (g *Game) replay(e Event) error {
+	target := func(i int) *Player {
+		if i < len(e.Targets) {
+			return g.GetPlayerByID(e.Targets[i])
+		}
+		return nil
+	}
+	targetID := func(i int) int {
+		if i < len(e.Targets) {
+			return e.Targets[i]
+		}
+		return 0
+	}
+	seat := func(i int) int {
+		if i < len(e.Targets) {
+			return g.IndexOfPlayer(e.Targets[i])
+		}
+		return -1
+	}
+
+	switch e.Kind {
+	case EventPhase:
+		return g.AdvancePhase()
+	case EventLife:
+		return g.SetPlayerAlive(seat(0), e.Result == "alive")
+	case EventSwap:
+		return g.SwapPlayers(seat(0), seat(1))
+	case EventRoleChange:
+		return g.SetPlayerRole(seat(0), e.Role)
+	case EventGhostVote:
+		return g.SetGhostVote(seat(0), e.Result == "used")
+	case EventRegistration:
+		return g.SetRegistration(seat(0), e.Result)
+	case EventRedHerring:
+		return g.SetRedHerring(seat(0))
+	case EventBluffs:
+		var bluffs []string
+		if e.Result != "" {
+			bluffs = strings.Split(e.Result, ", ")
+		}
+		return g.SetDemonBluffs(bluffs)
+	case EventReminderAdd:
+		return g.AddReminder(seat(0), Reminder{Role: e.Role, Token: e.Result})
+	case EventReminderRemove:
+		return g.RemoveReminder(seat(0), Reminder{Role: e.Role, Token: e.Result})
+	case EventNomination:
+		return g.Nominate(e.Actor, targetID(0))
+	case EventVote:
+		return g.CastVote(e.Result != "no")
+	case EventNominationCancel:
+		return g.CancelNomination()
+	case EventNightAction:
+		g.ResolveNightAction(e.Role, target(0))
+	case EventInfo:
+		g.ResolveInfoAction(e.Role, target(0), target(1), e.Result)
+	case EventFortuneTeller:
+		g.ResolveFortuneTeller(g.GetPlayerByID(e.Actor), target(0), target(1))
+	case EventChef:
+		given, err := strconv.Atoi(e.Result)
+		if err != nil {
+			return fmt.Errorf("invalid Chef number %q", e.Result)
+		}
+		g.ResolveChef(g.GetPlayerByID(e.Actor), given)
+	case EventCharacterInfo:
+		if e.Role == "Undertaker" {
+			g.ResolveUndertaker(g.GetPlayerByID(e.Actor), target(0), e.Result)
+		} else {
+			g.ResolveRavenkeeper(g.GetPlayerByID(e.Actor), target(0), e.Result)
+		}
+	case EventStarPass:
+		g.StarPass(g.GetPlayerByID(e.Actor), target(0))
+	case EventEvilInfo:
+		if e.Role == MinionInfoStep {
+			g.ResolveMinionInfo()
+		} else {
+			g.ResolveDemonInfo()
+		}
+	default:
+		return fmt.Errorf("cannot replay %s events", e.Kind)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package model
+
+import "testing"
+
+func TestRebuildMatchesLiveGame(t *testing.T) {
+	tests := []struct {
+		name  string
+		roles []string
+		play  func(g *Game)
+	}{
+		{"execution and night kill", []string{"Imp", "Poisoner", "Chef", "Empath", "Monk", "Undertaker", "Ravenkeeper"}, func(g *Game) {
+			g.Nominate(3, 2)
+			for g.NextVoter() != nil {
+				g.CastVote(true)
+			}
+			g.AdvancePhase()
+			g.ResolveNightAction("Poisoner", g.Players[3])
+			g.ResolveNightAction("Monk", g.Players[2])
+			g.ResolveNightAction("Imp", g.Players[6])
+			g.ResolveRavenkeeper(g.Players[6], g.Players[0], "Imp")
+			g.ResolveUndertaker(g.Players[5], g.ExecutedToday(), "Poisoner")
+			g.AdvancePhase()
+		}},
+		{"first night info", []string{"Imp", "Baron", "Chef", "Washerwoman", "Fortune Teller", "Drunk", "Recluse"}, func(g *Game) {
+			// Setup before the first night, like the setup wizard does
+			g.SetDrunkIdentity(5, "Empath")
+			g.Phase, g.Turn = PhaseNight, 1
+			g.Begin()
+
+			g.SetRegistration(6, "Minion")
+			g.SetRedHerring(2)
+			g.SetDemonBluffs([]string{"Monk", "Mayor"})
+			g.ResolveMinionInfo()
+			g.ResolveDemonInfo()
+			g.ResolveInfoAction("Washerwoman", g.Players[2], g.Players[4], "Chef")
+			g.ResolveChef(g.Players[2], g.ChefCount())
+			g.ResolveFortuneTeller(g.Players[4], g.Players[0], g.Players[1])
+		}},
+		{"star pass and edits", []string{"Imp", "Scarlet Woman", "Chef", "Empath", "Monk", "Butler"}, func(g *Game) {
+			g.SwapPlayers(2, 3)
+			g.SetPlayerRole(4, "Soldier")
+			g.AddReminder(3, Reminder{Role: "Butler", Token: "Master"})
+			g.RemoveReminder(3, Reminder{Role: "Butler", Token: "Master"})
+			g.Nominate(1, 3)
+			g.CastVote(false)
+			g.CancelNomination()
+			g.SetPlayerAlive(5, false)
+			g.SetGhostVote(5, true)
+			g.AdvancePhase()
+			g.StarPass(g.Players[0], g.Players[1])
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame(t, tt.roles...)
+			tt.play(g)
+			if len(g.Events) == 0 {
+				t.Fatal("nothing was logged")
+			}
+			live := mustJSON(t, g.GameState)
+
+			if err := g.Rebuild(); err != nil {
+				t.Fatal(err)
+			}
+			if rebuilt := mustJSON(t, g.GameState); rebuilt != live {
+				t.Errorf("rebuilt state differs from the live game\nlive:    %s\nrebuilt: %s", live, rebuilt)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package model
+
+import "fmt"
+
+type Team string
+
+const (
+	TeamGood Team = "Good"
+	TeamEvil Team = "Evil"
+)
+
+// Execution records the outcome of a day. PlayerID is 0 when nobody was executed.
+type Execution struct {
+	Turn     int    `json:"turn"`
+	PlayerID int    `json:"player_id"`
+	Role     string `json:"role"`
+	Votes    int    `json:"votes"`
+}
+
+// endDay executes whoever is on the block, applies execution-related
+// abilities and records the result. Nominations are cleared afterwards.
+func (g *Game) endDay() Execution {
+	block, votes := g.OnTheBlock()
+	exec := Execution{Turn: g.Turn, Votes: votes}
+	defer g.clearNominations()
+
+	if block == nil {
+		g.Executions = append(g.Executions, exec)
+		g.record(Event{Kind: EventExecution, Tag: "Day"}, "Nobody was executed")
+		g.resolveNoExecution()
+		return exec
+	}
+
+	exec.PlayerID = block.ID
+	exec.Role = block.Role.Name
+	g.Executions = append(g.Executions, exec)
+	g.record(Event{Kind: EventExecution, Tag: "Day", Targets: []int{block.ID}, Role: block.Role.Name, Result: fmt.Sprint(votes)},
+		"%s (%s) was executed with %d votes", block.Name, block.Role.Name, votes)
+
+	g.killPlayer(block)
+
+	// Saint: executed Saint loses the game for good
+	if block.Role.Name == "Saint" && !block.IsMalfunctioning() {
+		g.declareWinner(TeamEvil, fmt.Sprintf("The Saint (%s) was executed", block.Name))
+	}
+	return exec
+}
+
+// LastExecution returns the most recent day's result, if any.
+func (g *Game) LastExecution() *Execution {
+	if len(g.Executions) == 0 {
+		return nil
+	}
+	return &g.Executions[len(g.Executions)-1]
+}
+
+func (g *Game) resolveNoExecution() {
+	// Mayor: if only 3 players live and no execution occurs, good wins
+	alive := 0
+	for _, p := range g.Players {
+		if p.IsAlive {
+			alive++
+		}
+	}
+	if alive != 3 {
+		return
+	}
+	for _, p := range g.Players {
+		if p.IsAlive && p.Role.Name == "Mayor" && !p.IsMalfunctioning() {
+			g.declareWinner(TeamGood, fmt.Sprintf("The Mayor (%s) survived to the final 3 with no execution", p.Name))
+			return
+		}
+	}
+}
+
+// killPlayer marks a player dead and handles abilities triggered by the death.
+func (g *Game) killPlayer(p *Player) {
+	if !p.IsAlive {
+		return
+	}
+	aliveBefore := 0
+	for _, other := range g.Players {
+		if other.IsAlive {
+			aliveBefore++
+		}
+	}
+	g.markDead(p)
+
+	if p.Role.Type == Demon {
+		g.catchDemon(p, aliveBefore)
+	}
+	g.CheckWinConditions()
+}
+
+// markDead records a player's death without the abilities it can trigger
+// (see killPlayer): their effects on others end, since their ability stops
+// working.
+func (g *Game) markDead(p *Player) {
+	p.IsAlive = false
+	p.Death = &Death{Turn: g.Turn, Phase: g.Phase}
+	g.endEffectsFrom(p)
+}
+
+// SetPlayerAlive is the manual life toggle used by the storyteller. Deaths go
+// through the same handling as executions and demon kills.
+func (g *Game) SetPlayerAlive(idx int, alive bool) error {
+	if idx < 0 || idx >= len(g.Players) {
+		return fmt.Errorf("invalid player index")
+	}
+	p := g.Players[idx]
+	return g.act(Event{Kind: EventLife, Tag: "Storyteller", Targets: []int{p.ID}}, func(e *Event) error {
+		if alive {
+			g.revivePlayer(p)
+			e.Result = "alive"
+		} else {
+			g.killPlayer(p)
+			e.Result = "dead"
+		}
+		e.Message = fmt.Sprintf("%s marked %s", p.Name, e.Result)
+		return nil
+	})
+}
+
+// revivePlayer brings a player back, usually to correct a mistaken death.
+// A Scarlet Woman promoted by that death goes back to being a Minion, and a
+// finished game is reopened and checked again, so only the conditions that
+// depend on who is alive can end it straight away.
+func (g *Game) revivePlayer(p *Player) {
+	if p.IsAlive {
+		return
+	}
+	death := p.Death
+	p.IsAlive = true
+	p.Death = nil
+
+	if p.Role.Type == Demon && death != nil {
+		g.uncatchDemon(p, death.Turn)
+	}
+	if g.Winner != "" {
+		g.Winner = ""
+		g.WinReason = ""
+		g.record(Event{Kind: EventGameResume, Tag: "Game", Targets: []int{p.ID}}, "The game continues: %s is alive again", p.Name)
+		g.CheckWinConditions()
+	}
+}
+
+// uncatchDemon reverses catchDemon for a Scarlet Woman who became the Demon
+// on the given turn.
+func (g *Game) uncatchDemon(demon *Player, turn int) {
+	for _, p := range g.Players {
+		n := len(p.RoleHistory)
+		if n == 0 || p.Role.Name != demon.Role.Name {
+			continue
+		}
+		last := p.RoleHistory[n-1]
+		if last.Role != "Scarlet Woman" || last.Reason != "Scarlet Woman" || last.Turn != turn {
+			continue
+		}
+		role, ok := g.Script.FindRole("Scarlet Woman")
+		if !ok {
+			return
+		}
+		p.Role = role
+		p.RoleHistory = p.RoleHistory[:n-1]
+		if r, ok := g.resolverReminder("Scarlet Woman"); ok {
+			p.removeReminder(r)
+		}
+		g.record(Event{Kind: EventRoleChange, Tag: string(g.Phase), Targets: []int{p.ID}, Role: "Scarlet Woman"},
+			"%s is the Scarlet Woman again", p.Name)
+		return
+	}
+}
+
+// catchDemon lets the Scarlet Woman become the Demon if 5 or more players
+// were alive when the Demon died.
+func (g *Game) catchDemon(demon *Player, aliveBefore int) {
+	if aliveBefore < 5 {
+		return
+	}
+	for i, p := range g.Players {
+		if p.IsAlive && p.Role.Name == "Scarlet Woman" && !p.IsMalfunctioning() {
+			if err := g.changeRole(i, demon.Role.Name, "Scarlet Woman"); err != nil {
+				return
+			}
+			g.placeResolverReminder("Scarlet Woman", p)
+			g.record(Event{Kind: EventRoleChange, Tag: string(g.Phase), Targets: []int{p.ID}, Role: demon.Role.Name},
+				"Scarlet Woman %s becomes the %s", p.Name, demon.Role.Name)
+			return
+		}
+	}
+}
+
+func (g *Game) declareWinner(team Team, reason string) {
+	if g.Winner != "" {
+		return
+	}
+	g.Winner = team
+	g.WinReason = reason
+	g.record(Event{Kind: EventGameOver, Tag: "Game", Result: string(team)}, "%s wins: %s", team, reason)
+}
+
+// CheckWinConditions evaluates the game-ending conditions that depend only on
+// who is alive. It runs after every death and records the winner, if any.
+func (g *Game) CheckWinConditions() Team {
+	if g.Winner != "" {
+		return g.Winner
+	}
+
+	alive := 0
+	demonAlive := false
+	for _, p := range g.Players {
+		if !p.IsAlive {
+			continue
+		}
+		alive++
+		if p.Role.Type == Demon {
+			demonAlive = true
+		}
+	}
+
+	switch {
+	case !demonAlive:
+		g.declareWinner(TeamGood, "The Demon is dead")
+	case alive <= 2:
+		g.declareWinner(TeamEvil, "Only two players remain alive with the Demon")
+	}
+	return g.Winner
+}
+
+// TeamOf returns the team the player's character belongs to.
+func TeamOf(p *Player) Team {
+	if p.Role.Type == Minion || p.Role.Type == Demon {
+		return TeamEvil
+	}
+	return TeamGood
+}
@@ -0,0 +1,256 @@
+package model
+
+import "testing"
+
+type nomination struct{ nominator, nominee, yes int }
+
+// vote runs a nomination to the end, with the first yes voters in the vote
+// order raising their hands.
+func vote(t *testing.T, g *Game, n nomination) {
+	t.Helper()
+	if err := g.Nominate(n.nominator, n.nominee); err != nil {
+		t.Fatal(err)
+	}
+	for raised := 0; g.CurrentNomination() != nil; raised++ {
+		if err := g.CastVote(raised < n.yes); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestEndDay(t *testing.T) {
+	tests := []struct {
+		name         string
+		roles        []string
+		dead         []int // Seats killed before the votes
+		votes        []nomination
+		wantExecuted int // Player ID, 0 for nobody
+		wantWinner   Team
+		wantRoles    map[int]string // Seat to character after the execution
+	}{
+		{name: "no nominations", roles: []string{"Imp", "Poisoner", "Chef", "Empath", "Monk"}},
+		{name: "below the threshold", roles: []string{"Imp", "Poisoner", "Chef", "Empath", "Monk"},
+			votes: []nomination{{1, 3, 2}}},
+		{name: "reaches the threshold", roles: []string{"Imp", "Poisoner", "Chef", "Empath", "Monk"},
+			votes: []nomination{{1, 3, 3}}, wantExecuted: 3},
+		{name: "highest vote wins", roles: []string{"Imp", "Poisoner", "Chef", "Empath", "Monk"},
+			votes: []nomination{{1, 3, 3}, {2, 4, 4}}, wantExecuted: 4},
+		{name: "tie means no execution", roles: []string{"Imp", "Poisoner", "Chef", "Empath", "Monk"},
+			votes: []nomination{{1, 3, 3}, {2, 4, 3}}},
+		{name: "higher vote breaks a tie", roles: []string{"Imp", "Poisoner", "Chef", "Empath", "Monk"},
+			votes: []nomination{{1, 3, 3}, {2, 4, 3}, {5, 2, 4}}, wantExecuted: 2},
+		{name: "executed Saint loses the game", roles: []string{"Imp", "Poisoner", "Saint", "Chef", "Empath"},
+			votes: []nomination{{1, 3, 3}}, wantExecuted: 3, wantWinner: TeamEvil},
+		{name: "Mayor at the final three with no execution", roles: []string{"Imp", "Poisoner", "Mayor", "Chef", "Empath"},
+			dead: []int{1, 3}, wantWinner: TeamGood},
+		{name: "Mayor at the final three with an execution", roles: []string{"Imp", "Poisoner", "Mayor", "Chef", "Empath"},
+			dead: []int{1, 3}, votes: []nomination{{3, 5, 2}}, wantExecuted: 5, wantWinner: TeamEvil},
+		{name: "Scarlet Woman catches the Demon", roles: []string{"Imp", "Scarlet Woman", "Chef", "Empath", "Monk"},
+			votes: []nomination{{3, 1, 3}}, wantExecuted: 1, wantRoles: map[int]string{1: "Imp"}},
+		{name: "Scarlet Woman needs five alive", roles: []string{"Imp", "Scarlet Woman", "Chef", "Empath", "Monk"},
+			dead: []int{4}, votes: []nomination{{3, 1, 2}}, wantExecuted: 1, wantWinner: TeamGood,
+			wantRoles: map[int]string{1: "Scarlet Woman"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame(t, tt.roles...)
+			for _, seat := range tt.dead {
+				if err := g.SetPlayerAlive(seat, false); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for _, n := range tt.votes {
+				vote(t, g, n)
+			}
+			if err := g.AdvancePhase(); err != nil {
+				t.Fatal(err)
+			}
+
+			exec := g.LastExecution()
+			if exec == nil || exec.Turn != 1 {
+				t.Fatalf("LastExecution() = %+v, want one for day 1", exec)
+			}
+			if exec.PlayerID != tt.wantExecuted {
+				t.Errorf("executed player %d, want %d", exec.PlayerID, tt.wantExecuted)
+			}
+			if tt.wantExecuted != 0 && g.GetPlayerByID(tt.wantExecuted).IsAlive {
+				t.Error("executed player is still alive")
+			}
+			if g.Winner != tt.wantWinner {
+				t.Errorf("Winner = %q (%s), want %q", g.Winner, g.WinReason, tt.wantWinner)
+			}
+			for seat, want := range tt.wantRoles {
+				if got := g.Players[seat].Role.Name; got != want {
+					t.Errorf("seat %d is the %s, want the %s", seat, got, want)
+				}
+			}
+			if len(g.Nominations) != 0 {
+				t.Errorf("nominations were not cleared: %+v", g.Nominations)
+			}
+		})
+	}
+}
+
+func TestVoteThreshold(t *testing.T) {
+	tests := []struct {
+		seats int
+		dead  []int
+		want  int
+	}{
+		{5, nil, 3},
+		{6, nil, 3},
+		{7, nil, 4},
+		{7, []int{2, 3}, 3},
+		{6, []int{1, 2, 3}, 2},
+	}
+	roles := []string{"Imp", "Poisoner", "Chef", "Empath", "Monk", "Soldier", "Mayor"}
+	for _, tt := range tests {
+		g := newTestGame(t, roles[:tt.seats]...)
+		for _, seat := range tt.dead {
+			g.SetPlayerAlive(seat, false)
+		}
+		if got := g.VoteThreshold(); got != tt.want {
+			t.Errorf("%d seats with %d dead: VoteThreshold() = %d, want %d", tt.seats, len(tt.dead), got, tt.want)
+		}
+	}
+}
+
+func TestOnTheBlockUsesThresholdAtNomination(t *testing.T) {
+	g := newTestGame(t, "Imp", "Poisoner", "Chef", "Empath", "Monk")
+	vote(t, g, nomination{1, 3, 2})
+	if block, votes := g.OnTheBlock(); block != nil || votes != 0 {
+		t.Fatalf("OnTheBlock() = %v, %d; want nobody below the threshold of 3", block, votes)
+	}
+
+	// Two deaths lower the threshold for later nominations only
+	g.SetPlayerAlive(3, false)
+	g.SetPlayerAlive(4, false)
+	vote(t, g, nomination{2, 4, 2})
+	block, votes := g.OnTheBlock()
+	if block == nil || block.ID != 4 || votes != 2 {
+		t.Errorf("OnTheBlock() = %v, %d; want P4 with 2", block, votes)
+	}
+}
+
+func TestCheckWinConditions(t *testing.T) {
+	tests := []struct {
+		name      string
+		roles     []string
+		overrides map[int]string
+		dead      []int
+		want      Team
+	}{
+		{name: "everyone alive", roles: []string{"Imp", "Poisoner", "Chef", "Empath", "Monk"}},
+		{name: "dead Demon", roles: []string{"Imp", "Poisoner", "Chef", "Empath", "Monk"}, dead: []int{0}, want: TeamGood},
+		{name: "three alive with the Demon", roles: []string{"Imp", "Poisoner", "Chef", "Empath", "Monk"}, dead: []int{1, 2}},
+		{name: "two alive with the Demon", roles: []string{"Imp", "Poisoner", "Chef", "Empath", "Monk"}, dead: []int{1, 2, 3}, want: TeamEvil},
+		{name: "two alive without the Demon", roles: []string{"Imp", "Poisoner", "Chef", "Empath", "Monk"}, dead: []int{0, 1, 2}, want: TeamGood},
+		{name: "dead Recluse registering as the Demon", roles: []string{"Imp", "Poisoner", "Recluse", "Empath", "Monk"},
+			overrides: map[int]string{2: "Demon"}, dead: []int{2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame(t, tt.roles...)
+			for seat, override := range tt.overrides {
+				g.Players[seat].RegistrationOverride = override
+			}
+			for _, seat := range tt.dead {
+				g.Players[seat].IsAlive = false
+			}
+			if got := g.CheckWinConditions(); got != tt.want {
+				t.Errorf("CheckWinConditions() = %q (%s), want %q", got, g.WinReason, tt.want)
+			}
+		})
+	}
+}
+
+func TestScarletWomanSave(t *testing.T) {
+	tests := []struct {
+		name      string
+		dead      []int // Seats dead before the Demon dies
+		wantSaved bool
+	}{
+		{"five alive", nil, true},
+		{"four alive", []int{4}, false},
+		{"poisoned Scarlet Woman", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame(t, "Imp", "Scarlet Woman", "Chef", "Empath", "Monk", "Soldier")
+			g.SetPlayerAlive(5, false)
+			for _, seat := range tt.dead {
+				g.SetPlayerAlive(seat, false)
+			}
+			sw := g.Players[1]
+			if tt.name == "poisoned Scarlet Woman" {
+				g.addEffect(sw, EffectPoisoned, nil, "Poisoner", UntilDusk)
+			}
+
+			if err := g.SetPlayerAlive(0, false); err != nil {
+				t.Fatal(err)
+			}
+			if saved := sw.Role.Name == "Imp"; saved != tt.wantSaved {
+				t.Fatalf("Scarlet Woman is the %s, saved = %v, want %v", sw.Role.Name, saved, tt.wantSaved)
+			}
+			want := TeamGood
+			if tt.wantSaved {
+				want = ""
+			}
+			if g.Winner != want {
+				t.Errorf("Winner = %q, want %q", g.Winner, want)
+			}
+		})
+	}
+}
+
+func TestRevivePlayer(t *testing.T) {
+	tests := []struct {
+		name       string
+		roles      []string
+		kill       []int // Seats killed in order
+		revive     int
+		wantBefore Team
+		wantAfter  Team
+	}{
+		{"Demon marked dead by mistake", []string{"Imp", "Poisoner", "Chef", "Empath", "Monk"},
+			[]int{0}, 0, TeamGood, ""},
+		{"caught Demon", []string{"Imp", "Scarlet Woman", "Chef", "Empath", "Monk"},
+			[]int{0}, 0, "", ""},
+		{"back above two alive", []string{"Imp", "Poisoner", "Chef", "Empath"},
+			[]int{2, 3}, 2, TeamEvil, ""},
+		{"Demon still dead", []string{"Imp", "Poisoner", "Chef", "Empath", "Monk"},
+			[]int{2, 0}, 2, TeamGood, TeamGood},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame(t, tt.roles...)
+			for _, seat := range tt.kill {
+				g.SetPlayerAlive(seat, false)
+			}
+			if g.Winner != tt.wantBefore {
+				t.Fatalf("Winner = %q before the revive, want %q", g.Winner, tt.wantBefore)
+			}
+
+			if err := g.SetPlayerAlive(tt.revive, true); err != nil {
+				t.Fatal(err)
+			}
+			if g.Winner != tt.wantAfter {
+				t.Errorf("Winner = %q (%s) after the revive, want %q", g.Winner, g.WinReason, tt.wantAfter)
+			}
+			if tt.wantAfter == "" && g.WinReason != "" {
+				t.Errorf("WinReason = %q, want it cleared", g.WinReason)
+			}
+			for i, p := range g.Players {
+				if p.Role.Name != tt.roles[i] || len(p.RoleHistory) != 0 {
+					t.Errorf("seat %d is the %s (history %v), want the %s", i, p.Role.Name, p.RoleHistory, tt.roles[i])
+				}
+				if len(p.Reminders) != 0 {
+					t.Errorf("%s still has reminders %v", p.Name, p.Reminders)
+				}
+			}
+			if revived := g.Players[tt.revive]; !revived.IsAlive || revived.Death != nil {
+				t.Errorf("revived player: alive %v, death %+v", revived.IsAlive, revived.Death)
+			}
+		})
+	}
+}
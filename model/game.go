@@ -1,9 +1,8 @@
 package model
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
+	"time"
 )
 
 type Phase string
@@ -16,25 +15,55 @@ const (
 
 type Script struct {
 	Name       string   `json:"name"`
+	Author     string   `json:"author,omitempty"`
+	Logo       string   `json:"logo,omitempty"`
 	Roles      []Role   `json:"roles"`
 	FirstNight []string `json:"first_night"`
 	OtherNight []string `json:"other_night"`
 }
 
-type GameSnapshot struct {
-	Players []*Player `json:"players"`
-	Phase   Phase     `json:"phase"`
-	Log     []string  `json:"log"`
+// FindRole looks up a role definition by name.
+func (s Script) FindRole(name string) (Role, bool) {
+	for _, r := range s.Roles {
+		if r.Name == name {
+			return r, true
+		}
+	}
+	return Role{}, false
+}
+
+// GameState is everything storyteller actions can change. Undo snapshots
+// cover all of it.
+type GameState struct {
+	Players     []*Player    `json:"players"`
+	Phase       Phase        `json:"phase"`
+	Script      Script       `json:"script"`
+	Turn        int          `json:"turn"`         // 1-indexed turn counter
+	Events      []Event      `json:"events"`       // The game log, see events.go
+	Nominations []Nomination `json:"nominations"`  // Today's nominations, cleared at dusk
+	Executions  []Execution  `json:"executions"`   // One entry per completed day
+	Winner      Team         `json:"winner"`       // Empty while the game is in progress
+	DemonBluffs []string     `json:"demon_bluffs"` // Good characters out of play shown to the Demon
+	WinReason   string       `json:"win_reason"`
 }
 
 type Game struct {
-	Players []*Player `json:"players"`
-	Phase   Phase     `json:"phase"`
-	Script  Script    `json:"script"`
-	Turn    int       `json:"turn"` // 1-indexed turn counter
-	Log     []string  `json:"log"`
-	// Do not persist history to avoid recursion/bloat
-	History []GameSnapshot `json:"-"`
+	SchemaVersion int `json:"schema_version"` // Save format version, see migrate.go
+
+	Name string `json:"name"` // Shown in the save picker
+	Slot string `json:"slot"` // Save file name, without extension
+	GameState
+	// Undo history, persisted as deltas so it survives a restart
+	History UndoStack `json:"history"`
+	// States undone since the last action, for redo
+	Future UndoStack `json:"future"`
+
+	// State after setup, replayed from by Rebuild
+	Initial snapshotFields `json:"initial,omitempty"`
+
+	acting    bool      // Inside Do
+	replaying bool      // Inside Rebuild: no undo history or saving
+	eventTime time.Time // Timestamp for the events of the current action
 }
 
 func (g *Game) GetAliveCounts() (good, evil int) {
@@ -57,11 +86,50 @@ func (g *Game) GetEffectiveRoleType(p *Player) string {
 	return string(p.Role.Type)
 }
 
+func (g *Game) GetPlayerByID(id int) *Player {
+	for _, p := range g.Players {
+		if p != nil && p.ID == id {
+			return p
+		}
+	}
+	return nil
+}
+
+// FindActor returns the player who wakes for a character at night. This
+// follows the role the player believes they have, so a Drunk who thinks they
+// are the Monk acts in the Monk's place. A living player is preferred so that
+// a dead Demon does not shadow the player who inherited the role.
+func (g *Game) FindActor(roleName string) *Player {
+	var dead *Player
+	for _, p := range g.Players {
+		if p == nil || p.ActingRole().Name != roleName {
+			continue
+		}
+		if p.IsAlive {
+			return p
+		}
+		if dead == nil {
+			dead = p
+		}
+	}
+	return dead
+}
+
+func (g *Game) IndexOfPlayer(id int) int {
+	for i, p := range g.Players {
+		if p != nil && p.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
 func NewGame() *Game {
 	return &Game{
-		Players: make([]*Player, 0),
-		Phase:   PhaseSetup,
-		History: make([]GameSnapshot, 0),
+		GameState: GameState{
+			Players: make([]*Player, 0),
+			Phase:   PhaseSetup,
+		},
 	}
 }
 
@@ -99,65 +167,54 @@ func (g *Game) GetNextLivingNeighbor(startIndex int, clockwise bool) *Player {
 
 // Logic: Memento & Persistence
 
-func (g *Game) Snapshot() {
-	// Deep copy players
-	playersCopy := make([]*Player, len(g.Players))
-	for i, p := range g.Players {
-		// Start with simple derefence copy
-		val := *p
-		// Copy slice fields if necessary
-		reminders := make([]string, len(p.Reminders))
-		copy(reminders, p.Reminders)
-		val.Reminders = reminders
-		playersCopy[i] = &val
+func (g *Game) Undo() error {
+	fields, ok := g.History.Pop()
+	if !ok {
+		return fmt.Errorf("no history to undo")
 	}
-
-	// Copy log
-	logCopy := make([]string, len(g.Log))
-	copy(logCopy, g.Log)
-
-	snap := GameSnapshot{
-		Players: playersCopy,
-		Phase:   g.Phase,
-		Log:     logCopy,
+	current := g.captureSnapshot()
+	if err := g.restoreSnapshot(fields); err != nil {
+		return err
 	}
+	g.Future.Push(current, UndoDepth)
 
-	g.History = append(g.History, snap)
+	// Auto-save after undo
+	g.SaveState()
+	return nil
 }
 
-func (g *Game) Undo() error {
-	if len(g.History) == 0 {
-		return fmt.Errorf("no history to undo")
+// Redo reapplies the most recently undone action.
+func (g *Game) Redo() error {
+	fields, ok := g.Future.Pop()
+	if !ok {
+		return fmt.Errorf("nothing to redo")
 	}
+	current := g.captureSnapshot()
+	if err := g.restoreSnapshot(fields); err != nil {
+		return err
+	}
+	g.History.Push(current, UndoDepth)
 
-	last := g.History[len(g.History)-1]
-	g.History = g.History[:len(g.History)-1]
-
-	// Restore
-	g.Players = last.Players
-	g.Phase = last.Phase
-	g.Log = last.Log
-
-	// Auto-save after undo
 	g.SaveState()
 	return nil
 }
 
-func (g *Game) SaveState() error {
-	// Simple JSON save
-	data, err := json.MarshalIndent(g, "", "  ")
+func (g *Game) captureSnapshot() snapshotFields {
+	fields, err := encodeSnapshot(g.GameState)
 	if err != nil {
-		return err
+		// Game state is plain data, so this is a programming error
+		panic("model: encoding snapshot: " + err.Error())
 	}
-	return os.WriteFile("game_state.json", data, 0644)
+	return fields
 }
 
-func (g *Game) LoadState() error {
-	data, err := os.ReadFile("game_state.json")
-	if err != nil {
-		return err
+func (g *Game) restoreSnapshot(fields snapshotFields) error {
+	var state GameState
+	if err := decodeSnapshot(fields, &state); err != nil {
+		return fmt.Errorf("corrupt undo history: %w", err)
 	}
-	return json.Unmarshal(data, g)
+	g.GameState = state
+	return nil
 }
 
 // Logic: Setup
@@ -197,21 +254,9 @@ func GetDistribution(playerCount int) (townsfolk, outsider, minion, demon int) {
 
 // Logic: Night Resolution
 
-func (g *Game) ResetNightChanges() {
-	for _, p := range g.Players {
-		p.ResetNightStatus()
-	}
-}
-
-func (g *Game) ResolveNightAction(actorName string, target *Player) string {
+func (g *Game) resolveNightAction(actorName string, target *Player) string {
 	// Find actor
-	var actor *Player
-	for _, p := range g.Players {
-		if p.Role.Name == actorName {
-			actor = p
-			break
-		}
-	}
+	actor := g.FindActor(actorName)
 	if actor == nil {
 		return fmt.Sprintf("Error: Actor %s not found", actorName)
 	}
@@ -220,37 +265,45 @@ func (g *Game) ResolveNightAction(actorName string, target *Player) string {
 	// Note: Strings should match JSON script exactly.
 	switch actorName {
 	case "Poisoner":
-		target.IsPoisoned = true
+		g.addEffect(target, EffectPoisoned, actor, actorName, UntilDusk)
+		g.placeResolverReminder(actorName, target)
 		return fmt.Sprintf("Poisoner poisoned %s", target.Name)
 
 	case "Monk":
-		if actor.IsPoisoned || actor.IsDrunk {
+		if actor.IsMalfunctioning() {
 			return fmt.Sprintf("Monk tried to protect %s but was malfunctioning", target.Name)
 		}
-		target.IsProtected = true
+		g.addEffect(target, EffectProtected, actor, actorName, UntilDawn)
+		g.placeResolverReminder(actorName, target)
 		return fmt.Sprintf("Monk protected %s", target.Name)
 
 	case "Imp":
 		// Check for malfunction
-		if actor.IsPoisoned || actor.IsDrunk {
+		if actor.IsMalfunctioning() {
 			return fmt.Sprintf("Imp attacked %s but was malfunctioning", target.Name)
 		}
 
+		// Killing themself passes the Demon to a Minion
+		if target == actor {
+			return g.starPass(actor, g.DefaultStarPassRecipient())
+		}
+
 		// Check defense
-		if target.IsProtected {
+		if target.IsProtected() {
 			return fmt.Sprintf("Imp attacked %s but they were protected!", target.Name)
 		}
-		if target.Role.Name == "Soldier" && !target.IsPoisoned && !target.IsDrunk {
+		if target.Role.Name == "Soldier" && !target.IsMalfunctioning() {
 			// Soldier cannot be killed by Demon
 			return fmt.Sprintf("Imp attacked Soldier %s! No effect.", target.Name)
 		}
 
 		// Kill
-		target.IsAlive = false
+		g.killPlayer(target)
+		g.placeResolverReminder(actorName, target)
 		return fmt.Sprintf("Imp killed %s!", target.Name)
 
 	case "Fortune Teller":
-		if actor.IsPoisoned || actor.IsDrunk {
+		if actor.IsMalfunctioning() {
 			return fmt.Sprintf("Fortune Teller checked %s (False Info due to malfunction)", target.Name)
 		}
 		// Basic info logging, actual Yes/No given by storyteller manually usually,
@@ -258,6 +311,7 @@ func (g *Game) ResolveNightAction(actorName string, target *Player) string {
 		return fmt.Sprintf("Fortune Teller checked %s", target.Name)
 
 	case "Butler":
+		g.placeResolverReminder(actorName, target)
 		return fmt.Sprintf("Butler chose master %s", target.Name)
 
 	case "Empath":
@@ -270,30 +324,24 @@ func (g *Game) ResolveNightAction(actorName string, target *Player) string {
 	return fmt.Sprintf("%s targeted %s", actorName, target.Name)
 }
 
-func (g *Game) ResolveInfoAction(actorName string, p1, p2 *Player, roleName string) string {
+func (g *Game) resolveInfoAction(actorName string, p1, p2 *Player, roleName string) string {
 	// Find actor
-	var actor *Player
-	for _, p := range g.Players {
-		if p.Role.Name == actorName {
-			actor = p
-			break
-		}
-	}
+	actor := g.FindActor(actorName)
 	if actor == nil {
 		return fmt.Sprintf("Error: Actor %s not found", actorName)
 	}
 
 	// Check malfunction
-	if actor.IsPoisoned || actor.IsDrunk {
+	if actor.IsMalfunctioning() {
 		return fmt.Sprintf("%s learned that %s or %s is %s (False Info)", actorName, p1.Name, p2.Name, roleName)
 	}
 
 	return fmt.Sprintf("%s learned that %s or %s is %s", actorName, p1.Name, p2.Name, roleName)
 }
 
-func (g *Game) ResolveFortuneTeller(actor *Player, p1, p2 *Player) string {
+func (g *Game) resolveFortuneTeller(actor *Player, p1, p2 *Player) string {
 	// Check malfunction
-	if actor.IsPoisoned || actor.IsDrunk {
+	if actor.IsMalfunctioning() {
 		// False info: The storyteller *could* lie, but usually a simple "NO" when it should be "YES" or vice versa is enough.
 		// However, since we are automating:
 		// Let's just flag it as unreliable.
@@ -357,7 +405,7 @@ func (g *Game) GetEmpathInfo(empath *Player) string {
 	}
 
 	// Malfunction
-	if empath.IsPoisoned || empath.IsDrunk {
+	if empath.IsMalfunctioning() {
 		// Return false info.
 		// True info can be 0, 1, 2.
 		// False info should be different.
@@ -371,6 +419,125 @@ func (g *Game) GetEmpathInfo(empath *Player) string {
 	return fmt.Sprintf("Reading: %d", count)
 }
 
+// Logic: Chef
+
+// ChefCount is the number of pairs of neighbors who register as evil, all the
+// way around the circle. Dead players still sit in their seats, so they count.
+func (g *Game) ChefCount() int {
+	n := len(g.Players)
+	pairs := n
+	if n < 3 {
+		// Two players are only one pair, however you go round
+		pairs = n - 1
+	}
+	count := 0
+	for i := 0; i < pairs; i++ {
+		if g.registersEvil(g.Players[i]) && g.registersEvil(g.Players[(i+1)%n]) {
+			count++
+		}
+	}
+	return count
+}
+
+// ChefFalseCount suggests a number for a drunk or poisoned Chef: one off the
+// truth, so it stays believable.
+func ChefFalseCount(truth int) int {
+	if truth == 0 {
+		return 1
+	}
+	return truth - 1
+}
+
+func (g *Game) registersEvil(p *Player) bool {
+	t := g.GetEffectiveRoleType(p)
+	return t == "Minion" || t == "Demon"
+}
+
+func (g *Game) resolveChef(given int) string {
+	truth := g.ChefCount()
+	if given != truth {
+		return fmt.Sprintf("Chef was shown %d for pairs of evil neighbors (true count: %d)", given, truth)
+	}
+	return fmt.Sprintf("Chef was shown %d for pairs of evil neighbors", given)
+}
+
+// Logic: Undertaker & Ravenkeeper
+
+// ExecutedToday is the player executed during the day before this night, nil
+// if nobody was.
+func (g *Game) ExecutedToday() *Player {
+	exec := g.LastExecution()
+	if g.Phase != PhaseNight || exec == nil || exec.Turn != g.Turn-1 {
+		return nil
+	}
+	return g.GetPlayerByID(exec.PlayerID)
+}
+
+// DiedTonight reports whether a player died during the current night.
+func (g *Game) DiedTonight(p *Player) bool {
+	return p != nil && !p.IsAlive && p.Death != nil &&
+		g.Phase == PhaseNight && p.Death.Phase == PhaseNight && p.Death.Turn == g.Turn
+}
+
+// RegisteredCharacter is the character a player shows up as: their own, or
+// for a registration override (Spy/Recluse) a character of that type, out of
+// play if there is one so it stays believable.
+func (g *Game) RegisteredCharacter(p *Player) string {
+	if p.RegistrationOverride == "" || p.RegistrationOverride == string(p.Role.Type) {
+		return p.Role.Name
+	}
+	regType := RoleType(p.RegistrationOverride)
+	if roles := g.NotInPlayRoles(regType); len(roles) > 0 {
+		return roles[0].Name
+	}
+	// Every character of that type is in play, e.g. the only Demon
+	for _, r := range g.Script.Roles {
+		if r.Type == regType && r.Name != p.Role.Name {
+			return r.Name
+		}
+	}
+	return p.Role.Name
+}
+
+// SuggestedCharacter is the character to show an actor who learns a player's
+// character. A drunk or poisoned actor gets a good character out of play
+// instead, which the storyteller may change.
+func (g *Game) SuggestedCharacter(actor, target *Player) string {
+	truth := g.RegisteredCharacter(target)
+	if actor == nil || !actor.IsMalfunctioning() {
+		return truth
+	}
+	for _, r := range g.BluffCandidates() {
+		if r.Name != truth {
+			return r.Name
+		}
+	}
+	for _, r := range g.Script.Roles {
+		if r.Name != truth {
+			return r.Name
+		}
+	}
+	return truth
+}
+
+func (g *Game) resolveUndertaker(executed *Player, shown string) string {
+	g.placeResolverReminder("Undertaker", executed)
+	return fmt.Sprintf("Undertaker learned that %s, executed today, is the %s%s", executed.Name, shown, trueCharacter(executed, shown))
+}
+
+func (g *Game) resolveRavenkeeper(target *Player, shown string) string {
+	return fmt.Sprintf("Ravenkeeper chose %s and learned they are the %s%s", target.Name, shown, trueCharacter(target, shown))
+}
+
+// trueCharacter notes a player's real character when they were shown as
+// something else.
+func trueCharacter(p *Player, shown string) string {
+	if shown == p.Role.Name {
+		return ""
+	}
+	return fmt.Sprintf(" (really the %s)", p.Role.Name)
+}
+
 // Logic: Fortune Teller
 func (g *Game) IsDemonOrRedHerring(p *Player) bool {
 	if p == nil {
@@ -386,19 +553,83 @@ func (g *Game) IsDemonOrRedHerring(p *Player) bool {
 	return false
 }
 
+// Logic: Drunk
+
+// NotInPlayRoles returns the script's roles of a type that no player holds or
+// believes they hold.
+func (g *Game) NotInPlayRoles(roleType RoleType) []Role {
+	inPlay := make(map[string]bool)
+	for _, p := range g.Players {
+		if p == nil {
+			continue
+		}
+		inPlay[p.Role.Name] = true
+		inPlay[p.ShownRole.Name] = true
+	}
+
+	var roles []Role
+	for _, r := range g.Script.Roles {
+		if r.Type == roleType && !inPlay[r.Name] {
+			roles = append(roles, r)
+		}
+	}
+	return roles
+}
+
+// SetDrunkIdentity sets the Townsfolk the Drunk believes they are. The Drunk
+// wakes as that character and is permanently malfunctioning.
+func (g *Game) SetDrunkIdentity(idx int, roleName string) error {
+	if idx < 0 || idx >= len(g.Players) {
+		return fmt.Errorf("invalid player index")
+	}
+	p := g.Players[idx]
+	if p.Role.Name != "Drunk" {
+		return fmt.Errorf("%s is not the Drunk", p.Name)
+	}
+
+	for _, r := range g.Script.Roles {
+		if r.Name == roleName {
+			if r.Type != Townsfolk {
+				return fmt.Errorf("the Drunk must believe they are a Townsfolk")
+			}
+			p.ShownRole = r
+			p.setDrunkCharacter(g.Turn)
+			return nil
+		}
+	}
+	return fmt.Errorf("role %s not found in script", roleName)
+}
+
 // Logic: Manual Edits
 
-func (g *Game) SwapPlayers(i, j int) {
+func (g *Game) SwapPlayers(i, j int) error {
 	if i < 0 || i >= len(g.Players) || j < 0 || j >= len(g.Players) {
-		return
+		return fmt.Errorf("invalid player index")
 	}
-	g.Players[i], g.Players[j] = g.Players[j], g.Players[i]
+	a, b := g.Players[i], g.Players[j]
+	return g.act(Event{Kind: EventSwap, Tag: "Storyteller", Targets: []int{a.ID, b.ID}}, func(e *Event) error {
+		g.Players[i], g.Players[j] = b, a
+		e.Message = fmt.Sprintf("Swapped the seats of %s and %s", a.Name, b.Name)
+		return nil
+	})
 }
 
 func (g *Game) SetPlayerRole(idx int, roleName string) error {
 	if idx < 0 || idx >= len(g.Players) {
 		return fmt.Errorf("invalid player index")
 	}
+	p := g.Players[idx]
+	return g.act(Event{Kind: EventRoleChange, Tag: "Storyteller", Targets: []int{p.ID}, Role: roleName}, func(e *Event) error {
+		e.Message = fmt.Sprintf("%s is now the %s", p.Name, roleName)
+		return g.changeRole(idx, roleName, "Storyteller")
+	})
+}
+
+// changeRole swaps a player's character, keeping the old one in their history.
+func (g *Game) changeRole(idx int, roleName, reason string) error {
+	if idx < 0 || idx >= len(g.Players) {
+		return fmt.Errorf("invalid player index")
+	}
 
 	// Find role definition
 	var newRole Role
@@ -417,6 +648,21 @@ func (g *Game) SetPlayerRole(idx int, roleName string) error {
 	// Persist status? or reset? usually reset or keep generic status.
 	// For simplicity, keep status logic but update role data.
 	// Important: Maintain ID/Name, change Role struct.
-	g.Players[idx].Role = newRole
+	p := g.Players[idx]
+	if p.Role.Name == newRole.Name {
+		return nil
+	}
+	if p.Role.Name != "" {
+		p.RoleHistory = append(p.RoleHistory, RoleChange{
+			Role:   p.Role.Name,
+			Turn:   g.Turn,
+			Reason: reason,
+		})
+	}
+	p.Role = newRole
+
+	// Drunk identity only applies while the player is the Drunk
+	p.ShownRole = Role{}
+	p.setDrunkCharacter(g.Turn)
 	return nil
 }
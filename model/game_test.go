@@ -0,0 +1,89 @@
+package model
+
+import "testing"
+
+func TestRegisteredCharacter(t *testing.T) {
+	tests := []struct {
+		name     string
+		roles    []string
+		override string // Registration of the last player
+		want     string // Empty for any character of that type out of play
+	}{
+		{"no override", []string{"Imp", "Poisoner", "Recluse"}, "", "Recluse"},
+		{"override to own type", []string{"Imp", "Poisoner", "Recluse"}, "Outsider", "Recluse"},
+		{"Recluse as the Demon", []string{"Imp", "Poisoner", "Chef", "Recluse"}, "Demon", "Imp"},
+		{"Recluse as a Minion", []string{"Imp", "Poisoner", "Chef", "Recluse"}, "Minion", ""},
+		{"Spy as a Townsfolk", []string{"Imp", "Washerwoman", "Chef", "Spy"}, "Townsfolk", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame(t, tt.roles...)
+			p := g.Players[len(g.Players)-1]
+			p.RegistrationOverride = tt.override
+
+			got := g.RegisteredCharacter(p)
+			if tt.want != "" {
+				if got != tt.want {
+					t.Errorf("RegisteredCharacter = %s, want %s", got, tt.want)
+				}
+				return
+			}
+			role, ok := g.Script.FindRole(got)
+			if !ok || string(role.Type) != tt.override {
+				t.Fatalf("RegisteredCharacter = %s, want a %s", got, tt.override)
+			}
+			for _, other := range g.Players {
+				if other.Role.Name == got {
+					t.Errorf("RegisteredCharacter = %s, which %s already is", got, other.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestChefCount(t *testing.T) {
+	tests := []struct {
+		name      string
+		roles     []string // In seat order
+		overrides map[int]string
+		dead      []int
+		want      int
+	}{
+		{name: "no evil neighbors", roles: []string{"Imp", "Chef", "Poisoner", "Empath", "Monk"}, want: 0},
+		{name: "one pair", roles: []string{"Imp", "Poisoner", "Chef", "Empath", "Monk"}, want: 1},
+		{name: "pair wraps from the last seat to the first", roles: []string{"Imp", "Chef", "Empath", "Monk", "Poisoner"}, want: 1},
+		{name: "three in a row is two pairs", roles: []string{"Chef", "Imp", "Poisoner", "Baron", "Empath"}, want: 2},
+		{name: "whole circle evil", roles: []string{"Imp", "Poisoner", "Spy"}, want: 3},
+		{name: "dead players still count", roles: []string{"Imp", "Poisoner", "Chef", "Empath", "Monk"},
+			dead: []int{0, 1}, want: 1},
+		{name: "Recluse registering as a Minion", roles: []string{"Imp", "Recluse", "Chef", "Empath", "Monk"},
+			overrides: map[int]string{1: "Minion"}, want: 1},
+		{name: "Recluse registering as good", roles: []string{"Imp", "Recluse", "Chef", "Empath", "Monk"}, want: 0},
+		{name: "Spy registering as a Townsfolk", roles: []string{"Imp", "Spy", "Chef", "Empath", "Monk"},
+			overrides: map[int]string{1: "Townsfolk"}, want: 0},
+		{name: "two players are one pair", roles: []string{"Imp", "Poisoner"}, want: 1},
+		{name: "two players, one evil", roles: []string{"Imp", "Chef"}, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame(t, tt.roles...)
+			for seat, override := range tt.overrides {
+				g.Players[seat].RegistrationOverride = override
+			}
+			for _, seat := range tt.dead {
+				g.Players[seat].IsAlive = false
+			}
+			if got := g.ChefCount(); got != tt.want {
+				t.Errorf("ChefCount() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChefFalseCount(t *testing.T) {
+	for truth, want := range map[int]int{0: 1, 1: 0, 2: 1, 3: 2} {
+		if got := ChefFalseCount(truth); got != want {
+			t.Errorf("ChefFalseCount(%d) = %d, want %d", truth, got, want)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"clocktower/data"
+	"fmt"
+	"io/fs"
+	"testing"
+)
+
+// newTestGame seats one player per character from Trouble Brewing, in order,
+// and starts the game in the first day. It has no save slot, so actions are
+// never written to disk.
+func newTestGame(t *testing.T, roles ...string) *Game {
+	t.Helper()
+	script := bundledScript(t, "trouble_brewing.json")
+
+	g := NewGame()
+	g.Script = script
+	for i, name := range roles {
+		role, ok := script.FindRole(name)
+		if !ok {
+			t.Fatalf("%s is not on the script", name)
+		}
+		p := NewPlayer(i+1, fmt.Sprintf("P%d", i+1))
+		p.Role = role
+		p.setDrunkCharacter(0)
+		g.Players = append(g.Players, p)
+	}
+	g.Phase = PhaseDay
+	g.Turn = 1
+	g.Begin()
+	return g
+}
+
+// bundledScript parses one of the scripts embedded in the binary.
+func bundledScript(t *testing.T, file string) Script {
+	t.Helper()
+	raw, err := fs.ReadFile(data.Scripts(), file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	script, err := ParseScript(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return script
+}
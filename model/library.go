@@ -0,0 +1,65 @@
+package model
+
+import (
+	_ "embed"
+	"encoding/json"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Character is a library entry: a role definition plus the metadata needed
+// to build scripts from a list of IDs.
+type Character struct {
+	ID      string `json:"id"`
+	Edition string `json:"edition"` // "tb", "bmr", "snv"
+	Role
+	FirstNight int  `json:"first_night"` // Position in the first night order, 0 if it does not wake
+	OtherNight int  `json:"other_night"` // Position in the other nights order, 0 if it does not wake
+	Setup      bool `json:"setup"`       // Changes the game setup (shown in [brackets] in the ability)
+}
+
+//go:embed characters.json
+var charactersJSON []byte
+
+var (
+	libraryOnce sync.Once
+	library     []Character
+	libraryByID map[string]Character
+)
+
+func loadLibrary() {
+	if err := json.Unmarshal(charactersJSON, &library); err != nil {
+		// The library is embedded at build time, so this is a programming error
+		panic("model: invalid embedded character library: " + err.Error())
+	}
+	libraryByID = make(map[string]Character, len(library))
+	for _, c := range library {
+		libraryByID[c.ID] = c
+	}
+}
+
+// Library returns every built-in character.
+func Library() []Character {
+	libraryOnce.Do(loadLibrary)
+	return library
+}
+
+// LookupCharacter finds a built-in character by ID. IDs are matched loosely,
+// so "fortune_teller", "FortuneTeller" and "fortuneteller" are the same.
+func LookupCharacter(id string) (Character, bool) {
+	libraryOnce.Do(loadLibrary)
+	c, ok := libraryByID[NormalizeID(id)]
+	return c, ok
+}
+
+// NormalizeID lowercases an ID and strips everything but letters and digits.
+func NormalizeID(id string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(id) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
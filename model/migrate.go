@@ -0,0 +1,181 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// CurrentSchemaVersion is written to every save. Bump it and append a step to
+// migrations whenever the save format changes.
+const CurrentSchemaVersion = 5
+
+// migrations[i] upgrades a save from version i to i+1. Saves are handled as
+// generic JSON so a step can rename or reshape fields that no longer exist in
+// the Go types.
+var migrations = []func(save map[string]any) error{
+	migrateV0,
+	migrateV1,
+	migrateV2,
+	migrateV3,
+	migrateV4,
+}
+
+// migrateSave upgrades raw save data to the current schema version.
+func migrateSave(data []byte) ([]byte, error) {
+	var save map[string]any
+	if err := json.Unmarshal(data, &save); err != nil {
+		return nil, fmt.Errorf("invalid save JSON: %w", err)
+	}
+
+	version := 0
+	if v, ok := save["schema_version"].(float64); ok {
+		version = int(v)
+	}
+	if version > CurrentSchemaVersion {
+		return nil, fmt.Errorf("save has schema version %d, this build supports up to %d", version, CurrentSchemaVersion)
+	}
+	if version == CurrentSchemaVersion {
+		return data, nil
+	}
+
+	for v := version; v < CurrentSchemaVersion; v++ {
+		if err := migrations[v](save); err != nil {
+			return nil, fmt.Errorf("migrating save from version %d: %w", v, err)
+		}
+	}
+	save["schema_version"] = CurrentSchemaVersion
+	return json.Marshal(save)
+}
+
+// decodeSave migrates and decodes a save file.
+func decodeSave(data []byte, g *Game) error {
+	migrated, err := migrateSave(data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(migrated, g)
+}
+
+// migrateV0 upgrades saves written before versioning. Those games had no
+// name, and the Drunk's is_drunk flag was cleared every night.
+func migrateV0(save map[string]any) error {
+	if name, _ := save["name"].(string); name == "" {
+		scriptName := "Untitled"
+		if script, ok := save["script"].(map[string]any); ok {
+			if n, _ := script["name"].(string); n != "" {
+				scriptName = n
+			}
+		}
+		save["name"] = scriptName
+	}
+
+	players, _ := save["players"].([]any)
+	for _, item := range players {
+		player, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		if role, ok := player["role"].(map[string]any); ok && role["name"] == "Drunk" {
+			player["is_drunk"] = true
+		}
+	}
+	return nil
+}
+
+// migrateV1 drops undo history recorded before snapshots covered the whole
+// game state: restoring one of those would reset the turn, script and
+// nominations.
+func migrateV1(save map[string]any) error {
+	delete(save, "history")
+	delete(save, "future")
+	return nil
+}
+
+// migrateV2 turns the plain text log into note events. The original turn and
+// time of each line are unknown. There is no starting state to rebuild from,
+// and the undo history is dropped because its snapshots hold the old log.
+func migrateV2(save map[string]any) error {
+	lines, _ := save["log"].([]any)
+	events := make([]any, 0, len(lines))
+	for _, item := range lines {
+		line, _ := item.(string)
+		tag, message := "Game", line
+		if rest, ok := strings.CutPrefix(line, "["); ok {
+			if t, m, ok := strings.Cut(rest, "] "); ok {
+				tag, message = t, m
+			}
+		}
+		events = append(events, map[string]any{
+			"kind":    string(EventNote),
+			"tag":     tag,
+			"message": message,
+		})
+	}
+	save["events"] = events
+	delete(save, "log")
+	delete(save, "history")
+	delete(save, "future")
+	return nil
+}
+
+// migrateV3 turns reminder names into tokens that record their character.
+// Older versions never placed reminders, so the owner is left empty.
+func migrateV3(save map[string]any) error {
+	players, _ := save["players"].([]any)
+	for _, item := range players {
+		player, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		names, _ := player["reminders"].([]any)
+		if len(names) == 0 {
+			continue
+		}
+		tokens := make([]any, 0, len(names))
+		for _, name := range names {
+			if token, ok := name.(string); ok {
+				tokens = append(tokens, map[string]any{"role": "", "token": token})
+			}
+		}
+		player["reminders"] = tokens
+	}
+	return nil
+}
+
+// migrateV4 turns the poisoned, drunk and protected flags into effects. The
+// flags did not record who caused them, so the usual character is assumed.
+// The starting state is upgraded as well; the undo history is dropped.
+func migrateV4(save map[string]any) error {
+	upgrade := func(players []any) {
+		for _, item := range players {
+			player, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			var effects []any
+			addEffect := func(flag, kind, source, duration string) {
+				if set, _ := player[flag].(bool); set {
+					effects = append(effects, map[string]any{
+						"kind": kind, "source_role": source, "duration": duration,
+					})
+				}
+				delete(player, flag)
+			}
+			addEffect("is_poisoned", string(EffectPoisoned), "Poisoner", string(UntilDusk))
+			addEffect("is_drunk", string(EffectDrunk), "Drunk", string(Permanent))
+			addEffect("is_protected", string(EffectProtected), "Monk", string(UntilDawn))
+			player["effects"] = effects
+		}
+	}
+
+	players, _ := save["players"].([]any)
+	upgrade(players)
+	if initial, ok := save["initial"].(map[string]any); ok {
+		players, _ := initial["players"].([]any)
+		upgrade(players)
+	}
+	delete(save, "history")
+	delete(save, "future")
+	return nil
+}
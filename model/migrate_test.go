@@ -0,0 +1,114 @@
+package model
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestMigrateBaselineSave(t *testing.T) {
+	data, err := os.ReadFile("testdata/baseline_save.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var g Game
+	if err := decodeSave(data, &g); err != nil {
+		t.Fatal(err)
+	}
+
+	if g.SchemaVersion != CurrentSchemaVersion {
+		t.Errorf("SchemaVersion = %d, want %d", g.SchemaVersion, CurrentSchemaVersion)
+	}
+	if g.Name != "Trouble Brewing" {
+		t.Errorf("Name = %q, want the script name", g.Name)
+	}
+	if g.Phase != PhaseNight || g.Turn != 2 || len(g.Players) != 5 {
+		t.Errorf("got %s %d with %d players, want Night 2 with 5", g.Phase, g.Turn, len(g.Players))
+	}
+
+	wantEvents := []Event{
+		{Kind: EventNote, Tag: "Game", Message: "Game started with 5 players"},
+		{Kind: EventNote, Tag: "Night", Message: "Poisoner poisoned Dave"},
+		{Kind: EventNote, Tag: "Game", Message: "Erin was executed"},
+	}
+	if len(g.Events) != len(wantEvents) {
+		t.Fatalf("got %d events, want %d", len(g.Events), len(wantEvents))
+	}
+	for i, want := range wantEvents {
+		got := g.Events[i]
+		if got.Kind != want.Kind || got.Tag != want.Tag || got.Message != want.Message {
+			t.Errorf("event %d = %s [%s] %q, want %s [%s] %q", i, got.Kind, got.Tag, got.Message, want.Kind, want.Tag, want.Message)
+		}
+	}
+
+	players := []struct {
+		name      string
+		effects   []Effect
+		reminders []Reminder
+	}{
+		{"Alice", nil, nil},
+		{"Bob", nil, nil},
+		{"Carol", []Effect{{Kind: EffectDrunk, SourceRole: "Drunk", Duration: Permanent}}, nil},
+		{"Dave", []Effect{
+			{Kind: EffectPoisoned, SourceRole: "Poisoner", Duration: UntilDusk},
+			{Kind: EffectProtected, SourceRole: "Monk", Duration: UntilDawn},
+		}, []Reminder{{Token: "Poisoned"}}},
+		{"Erin", nil, nil},
+	}
+	for i, want := range players {
+		p := g.Players[i]
+		if p.Name != want.name {
+			t.Fatalf("player %d = %s, want %s", i, p.Name, want.name)
+		}
+		if len(p.Effects) != len(want.effects) {
+			t.Errorf("%s effects = %v, want %v", p.Name, p.Effects, want.effects)
+		} else {
+			for j := range want.effects {
+				if p.Effects[j] != want.effects[j] {
+					t.Errorf("%s effect %d = %+v, want %+v", p.Name, j, p.Effects[j], want.effects[j])
+				}
+			}
+		}
+		if len(p.Reminders) != len(want.reminders) {
+			t.Errorf("%s reminders = %v, want %v", p.Name, p.Reminders, want.reminders)
+		} else {
+			for j := range want.reminders {
+				if p.Reminders[j] != want.reminders[j] {
+					t.Errorf("%s reminder %d = %+v, want %+v", p.Name, j, p.Reminders[j], want.reminders[j])
+				}
+			}
+		}
+	}
+	if erin := g.Players[4]; erin.IsAlive || !erin.UsedGhostVote || !erin.IsRedHerring {
+		t.Errorf("Erin lost her other fields: %+v", erin)
+	}
+}
+
+func TestMigrateSaveVersions(t *testing.T) {
+	tests := []struct {
+		name    string
+		save    string
+		wantErr string
+	}{
+		{"current version is untouched", fmt.Sprintf(`{"schema_version":%d,"name":"Keep","is_drunk":true}`, CurrentSchemaVersion), ""},
+		{"newer version is refused", fmt.Sprintf(`{"schema_version":%d,"name":"Future"}`, CurrentSchemaVersion+1), "supports up to"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := migrateSave([]byte(tt.save))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want one mentioning %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.save {
+				t.Errorf("migrateSave() = %s, want it unchanged", got)
+			}
+		})
+	}
+}
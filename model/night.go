@@ -0,0 +1,143 @@
+package model
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// Synthetic first-night steps that are not tied to a character
+const (
+	MinionInfoStep = "Minion Info"
+	DemonInfoStep  = "Demon Info"
+)
+
+// Evil team info is only given with 7 or more players
+const minPlayersForEvilInfo = 7
+
+// BuildNightQueue returns the steps to walk through tonight: the script's
+// night order filtered to characters in play, preceded by the Minion and
+// Demon info steps on the first night.
+func (g *Game) BuildNightQueue() []string {
+	list := g.Script.OtherNight
+
+	// If Turn is 1 (First Night), use FirstNight list
+	// Assuming Turn starts at 0 or 1. Let's make sure we increment it.
+	firstNight := g.Turn <= 1
+	if firstNight {
+		list = g.Script.FirstNight
+	}
+
+	var queue []string
+	if firstNight && len(g.Players) >= minPlayersForEvilInfo {
+		queue = append(queue, MinionInfoStep, DemonInfoStep)
+	}
+
+	// Filter queue: Only include roles that are actually in play
+	for _, roleName := range list {
+		if g.FindActor(roleName) != nil {
+			queue = append(queue, roleName)
+		}
+	}
+	return queue
+}
+
+func (g *Game) Minions() []*Player {
+	var minions []*Player
+	for _, p := range g.Players {
+		if p != nil && p.Role.Type == Minion {
+			minions = append(minions, p)
+		}
+	}
+	return minions
+}
+
+// Demon returns the current Demon, preferring a living one.
+func (g *Game) Demon() *Player {
+	var dead *Player
+	for _, p := range g.Players {
+		if p == nil || p.Role.Type != Demon {
+			continue
+		}
+		if p.IsAlive {
+			return p
+		}
+		if dead == nil {
+			dead = p
+		}
+	}
+	return dead
+}
+
+// Logic: Demon Bluffs
+
+// ProposeBluffs picks three good characters from the script that are not in
+// play (including the Drunk's believed character).
+func (g *Game) ProposeBluffs(r *rand.Rand) []string {
+	candidates := g.BluffCandidates()
+	r.Shuffle(len(candidates), func(i, j int) { candidates[i], candidates[j] = candidates[j], candidates[i] })
+
+	bluffs := make([]string, 0, 3)
+	for _, role := range candidates {
+		if len(bluffs) == 3 {
+			break
+		}
+		bluffs = append(bluffs, role.Name)
+	}
+	return bluffs
+}
+
+// BluffCandidates lists every good character the Demon could be shown.
+func (g *Game) BluffCandidates() []Role {
+	return append(g.NotInPlayRoles(Townsfolk), g.NotInPlayRoles(Outsider)...)
+}
+
+func (g *Game) SetDemonBluffs(bluffs []string) error {
+	if len(bluffs) > 3 {
+		return fmt.Errorf("the Demon is shown at most 3 bluffs")
+	}
+
+	candidates := make(map[string]bool)
+	for _, r := range g.BluffCandidates() {
+		candidates[r.Name] = true
+	}
+	for _, b := range bluffs {
+		if !candidates[b] {
+			return fmt.Errorf("%s is not a good character out of play", b)
+		}
+	}
+
+	list := strings.Join(bluffs, ", ")
+	return g.act(Event{Kind: EventBluffs, Tag: "Setup", Result: list}, func(e *Event) error {
+		g.DemonBluffs = bluffs
+		e.Message = fmt.Sprintf("Demon bluffs set to %s", list)
+		return nil
+	})
+}
+
+// Logic: Evil Team Info
+
+func (g *Game) resolveMinionInfo() string {
+	demon := g.Demon()
+	if demon == nil {
+		return "Minions woke but there is no Demon"
+	}
+	return fmt.Sprintf("Minions %s learned the Demon is %s", playerNames(g.Minions()), demon.Name)
+}
+
+func (g *Game) resolveDemonInfo() string {
+	demon := g.Demon()
+	if demon == nil {
+		return "Error: Demon not found"
+	}
+	return fmt.Sprintf("Demon %s learned their Minions are %s and the bluffs are %s",
+		demon.Name, playerNames(g.Minions()), strings.Join(g.DemonBluffs, ", "))
+}
+
+func playerNames(players []*Player) string {
+	names := make([]string, len(players))
+	for i, p := range players {
+		names[i] = p.Name
+	}
+	return strings.Join(names, ", ")
+}
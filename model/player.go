@@ -1,21 +1,35 @@
 package model
 
+// RoleChange records a character a player held before it was changed.
+type RoleChange struct {
+	Role   string `json:"role"`   // The previous character
+	Turn   int    `json:"turn"`   // Turn on which it changed
+	Reason string `json:"reason"` // e.g. "Star Pass", "Scarlet Woman", "Storyteller"
+}
+
+// Death records when a player died.
+type Death struct {
+	Turn  int   `json:"turn"`
+	Phase Phase `json:"phase"`
+}
+
 type Player struct {
-	ID      int    `json:"id"`
-	Name    string `json:"name"`
-	Role    Role   `json:"role"`
-	IsAlive bool   `json:"is_alive"`
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Role      Role   `json:"role"`       // True character
+	ShownRole Role   `json:"shown_role"` // Character the player believes they are (Drunk), empty otherwise
+	IsAlive   bool   `json:"is_alive"`
+	Death     *Death `json:"death,omitempty"` // When they last died, nil while alive
 
 	// Game State
-	UsedGhostVote        bool     `json:"used_ghost_vote"` // Has used their ghost vote?
-	Reminders            []string `json:"reminders"`
-	RegistrationOverride string   `json:"registration_override"` // "Townsfolk", "Outsider", "Minion", "Demon" or empty
-
-	// Status Flags
-	IsPoisoned   bool `json:"is_poisoned"`
-	IsDrunk      bool `json:"is_drunk"`
-	IsProtected  bool `json:"is_protected"`
-	IsRedHerring bool `json:"is_red_herring"` // For Fortune Teller
+	UsedGhostVote        bool         `json:"used_ghost_vote"` // Has used their ghost vote?
+	Reminders            []Reminder   `json:"reminders"`
+	RegistrationOverride string       `json:"registration_override"` // "Townsfolk", "Outsider", "Minion", "Demon" or empty
+	RoleHistory          []RoleChange `json:"role_history"`
+
+	// Poisoned, drunk and protected, with their source and duration
+	Effects      []Effect `json:"effects"`
+	IsRedHerring bool     `json:"is_red_herring"` // For Fortune Teller
 }
 
 func NewPlayer(id int, name string) *Player {
@@ -26,8 +40,23 @@ func NewPlayer(id int, name string) *Player {
 	}
 }
 
-func (p *Player) ResetNightStatus() {
-	p.IsPoisoned = false
-	p.IsDrunk = false
-	p.IsProtected = false
+// ActingRole returns the character the player acts as at night: the shown
+// role for the Drunk, their true role otherwise.
+func (p *Player) ActingRole() Role {
+	if p.ShownRole.Name != "" {
+		return p.ShownRole
+	}
+	return p.Role
+}
+
+// IsMalfunctioning reports whether the player's ability currently gives false
+// information or fails to work.
+func (p *Player) IsMalfunctioning() bool {
+	return p.IsPoisoned() || p.IsDrunk()
+}
+
+// BecameDemon reports whether the player inherited the Demon during the game
+// (Star Pass or Scarlet Woman) rather than starting as it.
+func (p *Player) BecameDemon() bool {
+	return p.Role.Type == Demon && len(p.RoleHistory) > 0
 }
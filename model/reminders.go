@@ -0,0 +1,122 @@
+package model
+
+import "fmt"
+
+// Reminder is a reminder token placed on a player, as on a physical grimoire.
+type Reminder struct {
+	Role  string `json:"role"` // Character the token belongs to
+	Token string `json:"token"`
+}
+
+func (r Reminder) String() string {
+	if r.Role == "" {
+		return r.Token
+	}
+	return fmt.Sprintf("%s: %s", r.Role, r.Token)
+}
+
+// Tokens placed by the night resolvers, and the phase at whose start they are
+// removed again.
+var resolverReminders = map[string]Phase{
+	"Poisoner":   PhaseNight, // Poisoned tonight and tomorrow day
+	"Monk":       PhaseDay,   // Safe tonight only
+	"Imp":        PhaseNight,
+	"Butler":     PhaseNight, // Master for tomorrow's votes
+	"Undertaker": PhaseDay,   // On the player executed today
+}
+
+// ReminderOptions lists the reminder tokens of every character in play,
+// including the one the Drunk believes they are.
+func (g *Game) ReminderOptions() []Reminder {
+	var options []Reminder
+	seen := make(map[string]bool)
+	add := func(r Role) {
+		if r.Name == "" || seen[r.Name] {
+			return
+		}
+		seen[r.Name] = true
+		for _, token := range r.Reminders {
+			options = append(options, Reminder{Role: r.Name, Token: token})
+		}
+	}
+	for _, p := range g.Players {
+		add(p.Role)
+		add(p.ShownRole)
+	}
+	return options
+}
+
+func (g *Game) AddReminder(idx int, r Reminder) error {
+	if idx < 0 || idx >= len(g.Players) {
+		return fmt.Errorf("invalid player index")
+	}
+	p := g.Players[idx]
+	e := Event{Kind: EventReminderAdd, Tag: "Storyteller", Targets: []int{p.ID}, Role: r.Role, Result: r.Token}
+	return g.act(e, func(e *Event) error {
+		p.Reminders = append(p.Reminders, r)
+		e.Message = fmt.Sprintf("Placed %s on %s", r, p.Name)
+		return nil
+	})
+}
+
+func (g *Game) RemoveReminder(idx int, r Reminder) error {
+	if idx < 0 || idx >= len(g.Players) {
+		return fmt.Errorf("invalid player index")
+	}
+	p := g.Players[idx]
+	e := Event{Kind: EventReminderRemove, Tag: "Storyteller", Targets: []int{p.ID}, Role: r.Role, Result: r.Token}
+	return g.act(e, func(e *Event) error {
+		if !p.removeReminder(r) {
+			return fmt.Errorf("%s has no %s token", p.Name, r)
+		}
+		e.Message = fmt.Sprintf("Removed %s from %s", r, p.Name)
+		return nil
+	})
+}
+
+func (p *Player) removeReminder(r Reminder) bool {
+	for i, have := range p.Reminders {
+		if have == r {
+			p.Reminders = append(p.Reminders[:i], p.Reminders[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// placeResolverReminder moves a character's first reminder token onto the
+// target, taking it off whoever had it from an earlier night.
+func (g *Game) placeResolverReminder(roleName string, target *Player) {
+	r, ok := g.resolverReminder(roleName)
+	if !ok || target == nil {
+		return
+	}
+	for _, p := range g.Players {
+		p.removeReminder(r)
+	}
+	target.Reminders = append(target.Reminders, r)
+}
+
+// resolverReminder is the token a character's resolver places: its first
+// reminder.
+func (g *Game) resolverReminder(roleName string) (Reminder, bool) {
+	role, ok := g.Script.FindRole(roleName)
+	if !ok || len(role.Reminders) == 0 {
+		return Reminder{}, false
+	}
+	return Reminder{Role: roleName, Token: role.Reminders[0]}, true
+}
+
+// expireReminders removes the resolver tokens whose effect ends as the given
+// phase begins.
+func (g *Game) expireReminders(phase Phase) {
+	for _, p := range g.Players {
+		kept := p.Reminders[:0]
+		for _, r := range p.Reminders {
+			if ends, ok := resolverReminders[r.Role]; !ok || ends != phase {
+				kept = append(kept, r)
+			}
+		}
+		p.Reminders = kept
+	}
+}
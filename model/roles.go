@@ -26,4 +26,8 @@ type Role struct {
 	Ability    string     `json:"ability"`
 	ActionType ActionType `json:"action_type"`
 	Reminders  []string   `json:"reminders"`
+	// Adjusts the character counts when this role is in play (e.g. Baron +2 Outsiders)
+	SetupModifier *Distribution `json:"setup_modifier,omitempty"`
+	// The storyteller may apply the modifier in reverse (e.g. Godfather -1 or +1 Outsider)
+	SetupReversible bool `json:"setup_reversible,omitempty"`
 }
@@ -0,0 +1,258 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Finished games are moved here, under the save directory
+const archiveDirName = "archive"
+
+// SaveDir is where games are saved: $XDG_DATA_HOME/clocktower/saves, falling
+// back to ~/.local/share/clocktower/saves.
+func SaveDir() string {
+	base := os.Getenv("XDG_DATA_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			home = "."
+		}
+		base = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(base, "clocktower", "saves")
+}
+
+func slotPath(slot string) string {
+	return filepath.Join(SaveDir(), slot+".json")
+}
+
+// backupPath holds the previous save of a slot. It does not end in .json so
+// it is not listed as a slot of its own.
+func backupPath(slot string) string {
+	return slotPath(slot) + ".bak"
+}
+
+// SaveSummary describes a saved game without loading all of it.
+type SaveSummary struct {
+	Name     string
+	Slot     string
+	Script   string
+	Turn     int
+	Phase    Phase
+	Winner   Team
+	Modified time.Time
+}
+
+// Label describes the save for the startup picker.
+func (s SaveSummary) Label() string {
+	status := fmt.Sprintf("%s, turn %d", s.Phase, s.Turn)
+	if s.Winner != "" {
+		status = fmt.Sprintf("%s won", s.Winner)
+	}
+	return fmt.Sprintf("%s · %s · %s · saved %s",
+		s.Name, s.Script, status, s.Modified.Format("2006-01-02 15:04"))
+}
+
+// ListSaves returns the games in progress in the save directory, most
+// recently saved first. A slot whose save is missing falls back to its
+// backup. Archived games and unreadable files are left out, and finished
+// games that were never archived (e.g. the storyteller quit on the game-over
+// screen) are archived now.
+func ListSaves() ([]SaveSummary, error) {
+	files, err := filepath.Glob(filepath.Join(SaveDir(), "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	backups, err := filepath.Glob(filepath.Join(SaveDir(), "*.json.bak"))
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var saves []SaveSummary
+	for _, file := range append(files, backups...) {
+		slot := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(file), ".bak"), ".json")
+		if seen[slot] {
+			continue
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+		var g Game
+		if err := g.loadFile(file); err != nil {
+			continue
+		}
+		seen[slot] = true
+		if g.Winner != "" {
+			archiveFile(slot, file)
+			continue
+		}
+		saves = append(saves, SaveSummary{
+			Name:     g.Name,
+			Slot:     slot,
+			Script:   g.Script.Name,
+			Turn:     g.Turn,
+			Phase:    g.Phase,
+			Winner:   g.Winner,
+			Modified: info.ModTime(),
+		})
+	}
+	sort.Slice(saves, func(i, j int) bool {
+		return saves[i].Modified.After(saves[j].Modified)
+	})
+	return saves, nil
+}
+
+// AssignSlot picks an unused save file name derived from the game's name.
+func (g *Game) AssignSlot() {
+	base := slotName(g.Name)
+	slot := base
+	for i := 2; ; i++ {
+		if !slotExists(slot) {
+			break
+		}
+		slot = fmt.Sprintf("%s-%d", base, i)
+	}
+	g.Slot = slot
+}
+
+// slotExists reports whether a slot has a save or a leftover backup.
+func slotExists(slot string) bool {
+	for _, path := range []string{slotPath(slot), backupPath(slot)} {
+		if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+			return true
+		}
+	}
+	return false
+}
+
+// slotName turns a game name into a file name: lowercase letters and digits
+// separated by dashes.
+func slotName(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && b.Len() > 0 {
+				b.WriteRune('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	if b.Len() == 0 {
+		return "game"
+	}
+	return b.String()
+}
+
+// SaveState writes the game to its slot. The new save goes to a temporary
+// file that is renamed into place, so a crash mid-write never leaves a
+// truncated save; the previous save is kept as a backup.
+func (g *Game) SaveState() error {
+	if g.Slot == "" {
+		return fmt.Errorf("game has no save slot")
+	}
+	dir := SaveDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	g.SchemaVersion = CurrentSchemaVersion
+	data, err := json.MarshalIndent(g, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, g.Slot+"-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // No-op once renamed
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	path := slotPath(g.Slot)
+	if err := os.Rename(path, backupPath(g.Slot)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// LoadState loads the game saved in a slot, upgrading older save formats.
+// If the save is missing or unreadable, the backup is tried instead.
+func (g *Game) LoadState(slot string) error {
+	err := g.loadFile(slotPath(slot))
+	if err != nil {
+		if backupErr := g.loadFile(backupPath(slot)); backupErr != nil {
+			return err
+		}
+	}
+	g.Slot = slot
+	return nil
+}
+
+func (g *Game) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var loaded Game
+	if err := decodeSave(data, &loaded); err != nil {
+		return fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	*g = loaded
+	return nil
+}
+
+// ArchiveState moves the game's save into the archive directory, so it no
+// longer shows up in the picker but is kept for reference.
+func (g *Game) ArchiveState() error {
+	if g.Slot == "" {
+		return nil
+	}
+	src := slotPath(g.Slot)
+	if _, err := os.Stat(src); errors.Is(err, os.ErrNotExist) {
+		// Only the backup is left
+		src = backupPath(g.Slot)
+	}
+	if err := archiveFile(g.Slot, src); err != nil {
+		return err
+	}
+	g.Slot = ""
+	return nil
+}
+
+// archiveFile moves a slot's save, or its backup, into the archive directory
+// under a timestamped name. The slot's backup is removed as well.
+func archiveFile(slot, src string) error {
+	dir := filepath.Join(SaveDir(), archiveDirName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	archived := fmt.Sprintf("%s-%s.json", slot, time.Now().Format("20060102-150405"))
+	if err := os.Rename(src, filepath.Join(dir, archived)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	os.Remove(backupPath(slot))
+	return nil
+}
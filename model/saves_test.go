@@ -0,0 +1,181 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// useSaveDir points SaveDir at a fresh temporary directory.
+func useSaveDir(t *testing.T) string {
+	t.Helper()
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	return SaveDir()
+}
+
+func savedGame(t *testing.T, name string, roles ...string) *Game {
+	t.Helper()
+	g := newTestGame(t, roles...)
+	g.Name = name
+	g.AssignSlot()
+	if err := g.SaveState(); err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestSlotName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Trouble Brewing 2026-10-16 19:10", "trouble-brewing-2026-10-16-19-10"},
+		{"  Friday Night!  ", "friday-night"},
+		{"Game #2", "game-2"},
+		{"Café Noir", "café-noir"},
+		{"", "game"},
+		{"!!!", "game"},
+	}
+	for _, tt := range tests {
+		if got := slotName(tt.name); got != tt.want {
+			t.Errorf("slotName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAssignSlot(t *testing.T) {
+	dir := useSaveDir(t)
+	var slots []string
+	for range 3 {
+		slots = append(slots, savedGame(t, "Friday Game", "Imp", "Poisoner", "Chef", "Empath", "Monk").Slot)
+	}
+	want := []string{"friday-game", "friday-game-2", "friday-game-3"}
+	for i := range want {
+		if slots[i] != want[i] {
+			t.Errorf("slot %d = %q, want %q", i, slots[i], want[i])
+		}
+	}
+
+	// A slot with only a backup left is still taken
+	if err := os.Rename(filepath.Join(dir, "friday-game.json"), filepath.Join(dir, "friday-game.json.bak")); err != nil {
+		t.Fatal(err)
+	}
+	g := NewGame()
+	g.Name = "Friday Game"
+	g.AssignSlot()
+	if g.Slot != "friday-game-4" {
+		t.Errorf("slot = %q, want friday-game-4", g.Slot)
+	}
+}
+
+func TestListSaves(t *testing.T) {
+	dir := useSaveDir(t)
+	older := savedGame(t, "Older", "Imp", "Poisoner", "Chef", "Empath", "Monk")
+	newer := savedGame(t, "Newer", "Imp", "Poisoner", "Chef", "Empath", "Monk")
+	finished := savedGame(t, "Finished", "Imp", "Poisoner", "Chef", "Empath", "Monk")
+	finished.SetPlayerAlive(0, false)
+	if finished.Winner == "" {
+		t.Fatal("killing the Imp did not end the game")
+	}
+	if err := finished.SaveState(); err != nil {
+		t.Fatal(err)
+	}
+	backupOnly := savedGame(t, "Backup Only", "Imp", "Poisoner", "Chef", "Empath", "Monk")
+	if err := os.Rename(slotPath(backupOnly.Slot), backupPath(backupOnly.Slot)); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	for i, slot := range []string{older.Slot, newer.Slot} {
+		mtime := now.Add(time.Duration(i-3) * time.Hour)
+		if err := os.Chtimes(slotPath(slot), mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "broken.json"), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	saves, err := ListSaves()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, s := range saves {
+		got = append(got, s.Slot)
+	}
+	want := []string{backupOnly.Slot, newer.Slot, older.Slot}
+	if len(got) != len(want) {
+		t.Fatalf("ListSaves() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ListSaves() = %v, want %v", got, want)
+			break
+		}
+	}
+	if saves[1].Name != "Newer" || saves[1].Script != "Trouble Brewing" || saves[1].Phase != PhaseDay || saves[1].Turn != 1 {
+		t.Errorf("summary = %+v", saves[1])
+	}
+
+	// The finished game went to the archive
+	if _, err := os.Stat(slotPath(finished.Slot)); !os.IsNotExist(err) {
+		t.Errorf("finished save is still in the save directory: %v", err)
+	}
+	archived, _ := filepath.Glob(filepath.Join(dir, archiveDirName, finished.Slot+"-*.json"))
+	if len(archived) != 1 {
+		t.Fatalf("archive has %v, want the finished game", archived)
+	}
+	var g Game
+	if err := g.loadFile(archived[0]); err != nil || g.Name != "Finished" || g.Winner != TeamGood {
+		t.Errorf("archived game = %q won by %q (%v)", g.Name, g.Winner, err)
+	}
+}
+
+func TestArchiveState(t *testing.T) {
+	tests := []struct {
+		name       string
+		backupOnly bool
+	}{
+		{"save and backup", false},
+		{"backup only", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := useSaveDir(t)
+			g := savedGame(t, "Archived", "Imp", "Poisoner", "Chef", "Empath", "Monk")
+			slot := g.Slot
+			g.SetPlayerAlive(2, false) // Saved again, so there is a backup
+			if tt.backupOnly {
+				os.Remove(slotPath(slot))
+			}
+
+			if err := g.ArchiveState(); err != nil {
+				t.Fatal(err)
+			}
+			if g.Slot != "" {
+				t.Errorf("Slot = %q after archiving, want none", g.Slot)
+			}
+			for _, path := range []string{slotPath(slot), backupPath(slot)} {
+				if _, err := os.Stat(path); !os.IsNotExist(err) {
+					t.Errorf("%s is still there", filepath.Base(path))
+				}
+			}
+			archived, _ := filepath.Glob(filepath.Join(dir, archiveDirName, slot+"-*.json"))
+			if len(archived) != 1 {
+				t.Fatalf("archive has %v, want one save", archived)
+			}
+			var loaded Game
+			if err := loaded.loadFile(archived[0]); err != nil {
+				t.Fatal(err)
+			}
+			if loaded.Name != "Archived" {
+				t.Errorf("archived game is %q", loaded.Name)
+			}
+			if saves, _ := ListSaves(); len(saves) != 0 {
+				t.Errorf("ListSaves() = %+v after archiving", saves)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ParseScript reads a script in either of the supported formats:
+//   - our own format: an object with inline role definitions and night orders
+//   - the official script tool format: an array of character IDs (strings or
+//     {"id": ...} objects), with an optional "_meta" entry for the name,
+//     author and logo. IDs are resolved against the built-in library and the
+//     night orders are built from the library's night order positions.
+func ParseScript(data []byte) (Script, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		return parseScriptTool(trimmed)
+	}
+
+	var script Script
+	if err := json.Unmarshal(trimmed, &script); err != nil {
+		return Script{}, fmt.Errorf("invalid script JSON: %w", err)
+	}
+	return script, nil
+}
+
+// scriptToolEntry covers the "_meta" entry, plain ID references and
+// homebrew characters defined inline.
+type scriptToolEntry struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Author     string   `json:"author"`
+	Logo       string   `json:"logo"`
+	Team       string   `json:"team"`
+	Ability    string   `json:"ability"`
+	Reminders  []string `json:"reminders"`
+	FirstNight int      `json:"firstNight"`
+	OtherNight int      `json:"otherNight"`
+	Setup      bool     `json:"setup"`
+}
+
+var scriptToolTeams = map[string]RoleType{
+	"townsfolk": Townsfolk,
+	"outsider":  Outsider,
+	"minion":    Minion,
+	"demon":     Demon,
+	"traveler":  Traveler,
+	"traveller": Traveler,
+}
+
+func parseScriptTool(data []byte) (Script, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return Script{}, fmt.Errorf("invalid script JSON: %w", err)
+	}
+
+	var script Script
+	var characters []Character
+	var unknown []string
+
+	for _, item := range raw {
+		var entry scriptToolEntry
+		if err := json.Unmarshal(item, &entry.ID); err != nil {
+			if err := json.Unmarshal(item, &entry); err != nil {
+				return Script{}, fmt.Errorf("invalid script entry %s: %w", item, err)
+			}
+		}
+
+		switch {
+		case entry.ID == "_meta":
+			script.Name = entry.Name
+			script.Author = entry.Author
+			script.Logo = entry.Logo
+		case entry.Team == "fabled":
+			// Fabled are not dealt to players
+		case entry.Team != "":
+			c, err := entry.homebrew()
+			if err != nil {
+				return Script{}, err
+			}
+			characters = append(characters, c)
+		default:
+			c, ok := LookupCharacter(entry.ID)
+			if !ok {
+				unknown = append(unknown, entry.ID)
+				continue
+			}
+			characters = append(characters, c)
+		}
+	}
+
+	if len(unknown) > 0 {
+		return Script{}, fmt.Errorf("unknown characters: %s", strings.Join(unknown, ", "))
+	}
+	if script.Name == "" {
+		script.Name = "Custom Script"
+	}
+
+	for _, c := range characters {
+		script.Roles = append(script.Roles, c.Role)
+	}
+	script.FirstNight = nightOrder(characters, func(c Character) int { return c.FirstNight })
+	script.OtherNight = nightOrder(characters, func(c Character) int { return c.OtherNight })
+	return script, nil
+}
+
+// homebrew builds a character defined inline in a script tool file.
+func (e scriptToolEntry) homebrew() (Character, error) {
+	roleType, ok := scriptToolTeams[strings.ToLower(e.Team)]
+	if !ok {
+		return Character{}, fmt.Errorf("character %q has unknown team %q", e.ID, e.Team)
+	}
+	name := e.Name
+	if name == "" {
+		name = e.ID
+	}
+	return Character{
+		ID: NormalizeID(e.ID),
+		Role: Role{
+			Name:       name,
+			Type:       roleType,
+			Ability:    e.Ability,
+			ActionType: ActionNone,
+			Reminders:  e.Reminders,
+		},
+		FirstNight: e.FirstNight,
+		OtherNight: e.OtherNight,
+		Setup:      e.Setup,
+	}, nil
+}
+
+// nightOrder lists the characters that wake, sorted by their position.
+func nightOrder(characters []Character, position func(Character) int) []string {
+	var waking []Character
+	for _, c := range characters {
+		if position(c) > 0 {
+			waking = append(waking, c)
+		}
+	}
+	sort.SliceStable(waking, func(i, j int) bool {
+		return position(waking[i]) < position(waking[j])
+	})
+
+	order := make([]string, len(waking))
+	for i, c := range waking {
+		order[i] = c.Name
+	}
+	return order
+}
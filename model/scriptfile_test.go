@@ -0,0 +1,94 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseScript(t *testing.T) {
+	tests := []struct {
+		file       string
+		name       string
+		author     string
+		roles      []string
+		firstNight []string
+		otherNight []string
+		wantErr    string
+	}{
+		{file: "tool_meta.json", name: "Tiny", author: "Someone",
+			roles:      []string{"Imp", "Chef", "Fortune Teller", "Poisoner"},
+			firstNight: []string{"Poisoner", "Chef", "Fortune Teller"},
+			otherNight: []string{"Poisoner", "Imp", "Fortune Teller"}},
+		{file: "tool_no_meta.json", name: "Custom Script",
+			roles: []string{"Imp", "Chef"}, firstNight: []string{"Chef"}, otherNight: []string{"Imp"}},
+		{file: "tool_unknown.json", wantErr: "unknown characters: chefff, not_a_character"},
+		{file: "tool_homebrew.json", name: "Homebrew",
+			roles: []string{"Imp", "Night Owl"}, firstNight: []string{"Night Owl"}, otherNight: []string{"Night Owl", "Imp"}},
+		{file: "tool_bad_team.json", wantErr: `character "oddball" has unknown team "jester"`},
+		{file: "tool_bad_entry.json", wantErr: "invalid script entry 42"},
+		{file: "own_format.json", name: "Own Format",
+			roles: []string{"Imp", "Chef"}, firstNight: []string{"Chef"}, otherNight: []string{"Imp"}},
+		{file: "truncated.json", wantErr: "invalid script JSON"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			raw, err := os.ReadFile(filepath.Join("testdata", "parse", tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			script, err := ParseScript(raw)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if script.Name != tt.name || script.Author != tt.author {
+				t.Errorf("script is %q by %q, want %q by %q", script.Name, script.Author, tt.name, tt.author)
+			}
+			var roles []string
+			for _, r := range script.Roles {
+				roles = append(roles, r.Name)
+			}
+			if !slices.Equal(roles, tt.roles) {
+				t.Errorf("roles = %v, want %v", roles, tt.roles)
+			}
+			if !slices.Equal(script.FirstNight, tt.firstNight) {
+				t.Errorf("first night = %v, want %v", script.FirstNight, tt.firstNight)
+			}
+			if !slices.Equal(script.OtherNight, tt.otherNight) {
+				t.Errorf("other nights = %v, want %v", script.OtherNight, tt.otherNight)
+			}
+		})
+	}
+}
+
+func TestParseScriptHomebrewRole(t *testing.T) {
+	raw, err := os.ReadFile(filepath.Join("testdata", "parse", "tool_homebrew.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	script, err := ParseScript(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	owl, ok := script.FindRole("Night Owl")
+	if !ok {
+		t.Fatal("Night Owl is missing")
+	}
+	if owl.Type != Townsfolk || owl.ActionType != ActionNone || owl.Ability != "Each night, learn something." ||
+		!slices.Equal(owl.Reminders, []string{"Seen"}) {
+		t.Errorf("Night Owl = %+v", owl)
+	}
+	imp, _ := script.FindRole("Imp")
+	if lib, _ := LookupCharacter("imp"); imp.Ability != lib.Ability || imp.ActionType != lib.ActionType {
+		t.Errorf("Imp = %+v, want the library definition", imp)
+	}
+}
@@ -0,0 +1,207 @@
+package model
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+)
+
+// Distribution is the number of characters of each type in play. It is also
+// used as a delta by roles that modify setup.
+type Distribution struct {
+	Townsfolk int `json:"townsfolk"`
+	Outsider  int `json:"outsider"`
+	Minion    int `json:"minion"`
+	Demon     int `json:"demon"`
+}
+
+func (d Distribution) Total() int {
+	return d.Townsfolk + d.Outsider + d.Minion + d.Demon
+}
+
+func (d Distribution) String() string {
+	return fmt.Sprintf("%d Townsfolk, %d Outsiders, %d Minions, %d Demons",
+		d.Townsfolk, d.Outsider, d.Minion, d.Demon)
+}
+
+// Delta describes the distribution as a change in counts, e.g. "-1
+// Townsfolk, +1 Outsider".
+func (d Distribution) Delta() string {
+	var parts []string
+	add := func(n int, one, many string) {
+		switch n {
+		case 0:
+		case 1, -1:
+			parts = append(parts, fmt.Sprintf("%+d %s", n, one))
+		default:
+			parts = append(parts, fmt.Sprintf("%+d %s", n, many))
+		}
+	}
+	add(d.Townsfolk, "Townsfolk", "Townsfolk")
+	add(d.Outsider, "Outsider", "Outsiders")
+	add(d.Minion, "Minion", "Minions")
+	add(d.Demon, "Demon", "Demons")
+	if len(parts) == 0 {
+		return "no change"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Negate returns the opposite change in counts.
+func (d Distribution) Negate() Distribution {
+	return Distribution{Townsfolk: -d.Townsfolk, Outsider: -d.Outsider, Minion: -d.Minion, Demon: -d.Demon}
+}
+
+// BaseDistribution wraps GetDistribution for a player count.
+func BaseDistribution(playerCount int) Distribution {
+	tf, out, minion, dem := GetDistribution(playerCount)
+	return Distribution{Townsfolk: tf, Outsider: out, Minion: minion, Demon: dem}
+}
+
+// ApplySetupModifiers adds the setup modifiers of the given roles to a base
+// distribution. Counts never drop below zero.
+func ApplySetupModifiers(base Distribution, roles []Role) Distribution {
+	d := base
+	for _, r := range roles {
+		if r.SetupModifier == nil {
+			continue
+		}
+		d.Townsfolk += r.SetupModifier.Townsfolk
+		d.Outsider += r.SetupModifier.Outsider
+		d.Minion += r.SetupModifier.Minion
+		d.Demon += r.SetupModifier.Demon
+	}
+	d.Townsfolk = max(d.Townsfolk, 0)
+	d.Outsider = max(d.Outsider, 0)
+	d.Minion = max(d.Minion, 0)
+	d.Demon = max(d.Demon, 0)
+	return d
+}
+
+// ReverseSetup returns a copy of the script where the named roles apply their
+// reversible setup modifiers in reverse, e.g. the Godfather's -1 Outsider.
+// The storyteller makes this choice before the characters are dealt.
+func (s Script) ReverseSetup(names ...string) (Script, error) {
+	s.Roles = slices.Clone(s.Roles)
+	for _, name := range names {
+		i := slices.IndexFunc(s.Roles, func(r Role) bool { return r.Name == name })
+		if i == -1 {
+			return s, fmt.Errorf("role %s not found in script", name)
+		}
+		r := &s.Roles[i]
+		if !r.SetupReversible || r.SetupModifier == nil {
+			return s, fmt.Errorf("the %s's setup cannot be reversed", name)
+		}
+		reversed := r.SetupModifier.Negate()
+		r.SetupModifier = &reversed
+	}
+	return s, nil
+}
+
+// DealRoles picks a random bag of characters for the script. Minions and the
+// Demon are chosen first, then their setup modifiers decide how many Outsiders
+// and Townsfolk to draw. Modifiers on the good characters drawn are applied in
+// turn until the bag is stable. The returned roles are shuffled.
+func DealRoles(script Script, playerCount int, r *rand.Rand) ([]Role, Distribution, error) {
+	byType := make(map[RoleType][]Role)
+	for _, role := range script.Roles {
+		byType[role.Type] = append(byType[role.Type], role)
+	}
+	for _, roles := range byType {
+		r.Shuffle(len(roles), func(i, j int) { roles[i], roles[j] = roles[j], roles[i] })
+	}
+
+	base := BaseDistribution(playerCount)
+	draw := func(t RoleType, n int) ([]Role, error) {
+		if n > len(byType[t]) {
+			return nil, fmt.Errorf("script has %d %s roles, %d needed", len(byType[t]), t, n)
+		}
+		return byType[t][:n], nil
+	}
+
+	demons, err := draw(Demon, base.Demon)
+	if err != nil {
+		return nil, base, err
+	}
+	minions, err := draw(Minion, base.Minion)
+	if err != nil {
+		return nil, base, err
+	}
+
+	evil := append(append([]Role{}, demons...), minions...)
+	applied := evil
+	var good []Role
+	dist := base
+	// Each pass can only add roles, so this settles within a few iterations
+	for range len(script.Roles) + 1 {
+		dist = ApplySetupModifiers(base, applied)
+
+		outsiders, err := draw(Outsider, dist.Outsider)
+		if err != nil {
+			return nil, dist, err
+		}
+		townsfolk, err := draw(Townsfolk, dist.Townsfolk)
+		if err != nil {
+			return nil, dist, err
+		}
+		good = append(append([]Role{}, townsfolk...), outsiders...)
+
+		var next []Role
+		next = append(next, evil...)
+		for _, role := range good {
+			if role.SetupModifier != nil {
+				next = append(next, role)
+			}
+		}
+		if len(next) == len(applied) {
+			break
+		}
+		applied = next
+	}
+
+	selected := append(evil, good...)
+	r.Shuffle(len(selected), func(i, j int) { selected[i], selected[j] = selected[j], selected[i] })
+	return selected, dist, nil
+}
+
+// CountBag tallies the character types in a bag.
+func CountBag(bag []Role) Distribution {
+	var d Distribution
+	for _, r := range bag {
+		switch r.Type {
+		case Townsfolk:
+			d.Townsfolk++
+		case Outsider:
+			d.Outsider++
+		case Minion:
+			d.Minion++
+		case Demon:
+			d.Demon++
+		}
+	}
+	return d
+}
+
+// ValidateBag checks a hand-picked bag against the distribution for the
+// player count, after the setup modifiers of the roles in the bag. It returns
+// the required distribution so callers can show progress.
+func ValidateBag(bag []Role, playerCount int) (Distribution, error) {
+	want := ApplySetupModifiers(BaseDistribution(playerCount), bag)
+	have := CountBag(bag)
+	if have == want {
+		return want, nil
+	}
+
+	var problems []string
+	check := func(name string, have, want int) {
+		if have != want {
+			problems = append(problems, fmt.Sprintf("%d %s (have %d)", want, name, have))
+		}
+	}
+	check("Townsfolk", have.Townsfolk, want.Townsfolk)
+	check("Outsiders", have.Outsider, want.Outsider)
+	check("Minions", have.Minion, want.Minion)
+	check("Demons", have.Demon, want.Demon)
+	return want, fmt.Errorf("need %s", strings.Join(problems, ", "))
+}
@@ -0,0 +1,197 @@
+package model
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestBaseDistribution(t *testing.T) {
+	want := map[int]Distribution{
+		5:  {3, 0, 1, 1},
+		6:  {3, 1, 1, 1},
+		7:  {5, 0, 1, 1},
+		8:  {5, 1, 1, 1},
+		9:  {5, 2, 1, 1},
+		10: {7, 0, 2, 1},
+		11: {7, 1, 2, 1},
+		12: {7, 2, 2, 1},
+		13: {9, 0, 3, 1},
+		14: {9, 1, 3, 1},
+		15: {9, 2, 3, 1},
+	}
+	for n := MinPlayers; n <= MaxPlayers; n++ {
+		got := BaseDistribution(n)
+		if got != want[n] {
+			t.Errorf("BaseDistribution(%d) = %s, want %s", n, got, want[n])
+		}
+		if got.Total() != n {
+			t.Errorf("BaseDistribution(%d) has %d characters", n, got.Total())
+		}
+	}
+}
+
+// withoutRoles drops roles from a script, e.g. to leave a single Minion.
+func withoutRoles(s Script, names ...string) Script {
+	s.Roles = slices.DeleteFunc(slices.Clone(s.Roles), func(r Role) bool {
+		return slices.Contains(names, r.Name)
+	})
+	return s
+}
+
+func TestDealRoles(t *testing.T) {
+	tb := bundledScript(t, "trouble_brewing.json")
+	bmr := bundledScript(t, "bad_moon_rising.json")
+	godfatherOnly := withoutRoles(bmr, "Devil's Advocate", "Assassin", "Mastermind")
+	godfatherReversed, err := godfatherOnly.ReverseSetup("Godfather")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type dealTest struct {
+		name    string
+		script  Script
+		players int
+		want    Distribution // Zero to only check the bag is consistent
+	}
+	tests := []dealTest{
+		{name: "Baron adds two Outsiders", script: withoutRoles(tb, "Poisoner", "Spy", "Scarlet Woman"),
+			players: 7, want: Distribution{3, 2, 1, 1}},
+		{name: "no Baron", script: withoutRoles(tb, "Baron"), players: 7, want: Distribution{5, 0, 1, 1}},
+		{name: "Godfather as written", script: godfatherOnly, players: 8, want: Distribution{4, 2, 1, 1}},
+		{name: "Godfather reversed", script: godfatherReversed, players: 8, want: Distribution{6, 0, 1, 1}},
+	}
+	for n := MinPlayers; n <= MaxPlayers; n++ {
+		tests = append(tests,
+			dealTest{name: "Trouble Brewing", script: tb, players: n},
+			dealTest{name: "Bad Moon Rising", script: bmr, players: n})
+	}
+
+	for _, tt := range tests {
+		// Several seeds, so random deals draw different modifiers
+		for seed := int64(1); seed <= 20; seed++ {
+			roles, dist, err := DealRoles(tt.script, tt.players, rand.New(rand.NewSource(seed)))
+			if err != nil {
+				t.Fatalf("%s, %d players: %v", tt.name, tt.players, err)
+			}
+			if len(roles) != tt.players {
+				t.Fatalf("%s, %d players: dealt %d roles", tt.name, tt.players, len(roles))
+			}
+			if got := CountBag(roles); got != dist {
+				t.Errorf("%s, %d players: bag has %s, reported %s", tt.name, tt.players, got, dist)
+			}
+			if want := ApplySetupModifiers(BaseDistribution(tt.players), roles); dist != want {
+				t.Errorf("%s, %d players: dealt %s, modifiers in play need %s", tt.name, tt.players, dist, want)
+			}
+			if tt.want != (Distribution{}) && dist != tt.want {
+				t.Errorf("%s, %d players: dealt %s, want %s", tt.name, tt.players, dist, tt.want)
+			}
+			seen := make(map[string]bool)
+			for _, r := range roles {
+				if seen[r.Name] {
+					t.Errorf("%s, %d players: %s dealt twice", tt.name, tt.players, r.Name)
+				}
+				seen[r.Name] = true
+			}
+		}
+	}
+}
+
+func TestDealRolesShortScript(t *testing.T) {
+	// The Saint alone cannot cover two Outsiders
+	script := withoutRoles(bundledScript(t, "trouble_brewing.json"), "Butler", "Drunk", "Recluse", "Baron")
+	_, _, err := DealRoles(script, 9, rand.New(rand.NewSource(1)))
+	if err == nil || !strings.Contains(err.Error(), "Outsider") {
+		t.Errorf("err = %v, want one about Outsiders", err)
+	}
+}
+
+func TestValidateBag(t *testing.T) {
+	tb := bundledScript(t, "trouble_brewing.json")
+	bmr := bundledScript(t, "bad_moon_rising.json")
+	reversed, err := bmr.ReverseSetup("Godfather")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bag := func(s Script, names ...string) []Role {
+		roles := make([]Role, len(names))
+		for i, name := range names {
+			r, ok := s.FindRole(name)
+			if !ok {
+				t.Fatalf("%s is not on the script", name)
+			}
+			roles[i] = r
+		}
+		return roles
+	}
+
+	tests := []struct {
+		name    string
+		bag     []Role
+		players int
+		want    Distribution
+		wantErr string
+	}{
+		{"base", bag(tb, "Imp", "Poisoner", "Chef", "Empath", "Monk"), 5, Distribution{3, 0, 1, 1}, ""},
+		{"Baron", bag(tb, "Imp", "Baron", "Chef", "Empath", "Monk", "Saint", "Drunk"), 7, Distribution{3, 2, 1, 1}, ""},
+		{"Baron without Outsiders", bag(tb, "Imp", "Baron", "Chef", "Empath", "Monk", "Mayor", "Soldier"), 7,
+			Distribution{3, 2, 1, 1}, "need 3 Townsfolk (have 5), 2 Outsiders (have 0)"},
+		{"Godfather as written", bag(bmr, "Zombuul", "Godfather", "Grandmother", "Sailor", "Chambermaid", "Goon", "Lunatic", "Moonchild"), 8,
+			Distribution{4, 2, 1, 1}, "need 4 Townsfolk (have 3), 2 Outsiders (have 3)"},
+		{"Godfather reversed", bag(reversed, "Zombuul", "Godfather", "Grandmother", "Sailor", "Chambermaid", "Exorcist", "Innkeeper", "Gambler"), 8,
+			Distribution{6, 0, 1, 1}, ""},
+		{"too many characters", bag(tb, "Imp", "Poisoner", "Chef", "Empath", "Monk", "Mayor"), 5,
+			Distribution{3, 0, 1, 1}, "need 3 Townsfolk (have 4)"},
+		{"too few characters", bag(tb, "Imp", "Poisoner", "Chef", "Empath"), 5,
+			Distribution{3, 0, 1, 1}, "need 3 Townsfolk (have 2)"},
+		{"wrong types", bag(tb, "Imp", "Spy", "Baron", "Chef", "Empath"), 5,
+			Distribution{1, 2, 1, 1}, "need 1 Townsfolk (have 2), 2 Outsiders (have 0), 1 Minions (have 2)"},
+		{"two Demons", bag(tb, "Imp", "Imp", "Chef", "Empath", "Monk"), 5,
+			Distribution{3, 0, 1, 1}, "need 1 Minions (have 0), 1 Demons (have 2)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := ValidateBag(tt.bag, tt.players)
+			if want != tt.want {
+				t.Errorf("required %s, want %s", want, tt.want)
+			}
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReverseSetup(t *testing.T) {
+	bmr := bundledScript(t, "bad_moon_rising.json")
+	reversed, err := bmr.ReverseSetup("Godfather")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := reversed.FindRole("Godfather")
+	if *got.SetupModifier != (Distribution{Townsfolk: 1, Outsider: -1}) {
+		t.Errorf("reversed Godfather modifier = %s", got.SetupModifier.Delta())
+	}
+	original, _ := bmr.FindRole("Godfather")
+	if *original.SetupModifier != (Distribution{Townsfolk: -1, Outsider: 1}) {
+		t.Errorf("original script changed: %s", original.SetupModifier.Delta())
+	}
+	if lib, _ := LookupCharacter("godfather"); *lib.SetupModifier != *original.SetupModifier {
+		t.Errorf("character library changed: %s", lib.SetupModifier.Delta())
+	}
+
+	if _, err := bmr.ReverseSetup("Zombuul"); err == nil {
+		t.Error("reversing a role without a setup modifier succeeded")
+	}
+	tb := bundledScript(t, "trouble_brewing.json")
+	if _, err := tb.ReverseSetup("Baron"); err == nil {
+		t.Error("reversing the Baron succeeded")
+	}
+}
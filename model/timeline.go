@@ -0,0 +1,64 @@
+package model
+
+import "fmt"
+
+// Chapter is one phase of the game in the replay timeline, covering
+// Events[Start:End].
+type Chapter struct {
+	Phase Phase
+	Turn  int
+	Start int
+	End   int
+}
+
+func (c Chapter) String() string {
+	if c.Phase == PhaseSetup {
+		return "Setup"
+	}
+	return fmt.Sprintf("%s %d", c.Phase, c.Turn)
+}
+
+// CanReplay reports whether the game recorded its starting state. Games
+// migrated from a plain text log cannot be replayed.
+func (g *Game) CanReplay() bool {
+	return g.Initial != nil
+}
+
+// Timeline splits the log into chapters at every phase change. The events an
+// action produced before its phase change (such as the execution at the end
+// of the day) belong to the chapter it starts, since that is where their
+// effect shows.
+func (g *Game) Timeline() []Chapter {
+	chapters := []Chapter{{Phase: PhaseSetup}}
+	for i, e := range g.Events {
+		if !e.Command || e.Kind != EventPhase {
+			continue
+		}
+		start := i
+		for start > chapters[len(chapters)-1].Start && !g.Events[start-1].Command &&
+			g.Events[start-1].Time.Equal(e.Time) {
+			start--
+		}
+		chapters[len(chapters)-1].End = start
+		chapters = append(chapters, Chapter{Phase: e.Phase, Turn: e.Turn, Start: start})
+	}
+	chapters[len(chapters)-1].End = len(g.Events)
+	return chapters
+}
+
+// StateAt returns a copy of the game as it was after the first n events. The
+// game itself is not changed.
+func (g *Game) StateAt(n int) (*Game, error) {
+	if n < 0 || n > len(g.Events) {
+		return nil, fmt.Errorf("event %d is out of range", n)
+	}
+	past := &Game{
+		Name:    g.Name,
+		Initial: g.Initial,
+	}
+	past.Events = g.Events
+	if err := past.rebuild(n); err != nil {
+		return nil, err
+	}
+	return past, nil
+}
@@ -0,0 +1,181 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// DefaultUndoDepth is how many snapshots are kept when UndoDepth is unset.
+const DefaultUndoDepth = 100
+
+// UndoDepth caps the undo history kept in memory and in save files.
+var UndoDepth = DefaultUndoDepth
+
+// snapshotFields is a snapshot encoded as its top-level JSON fields, so
+// snapshots can be diffed without knowing their Go types.
+type snapshotFields map[string]json.RawMessage
+
+// Delta turns one snapshot into another. Arrays (players, log) are patched
+// element by element; other fields are replaced wholesale.
+type Delta struct {
+	Set   map[string]json.RawMessage `json:"set,omitempty"`
+	Del   []string                   `json:"del,omitempty"`
+	Lists map[string]ListDelta       `json:"lists,omitempty"`
+}
+
+// ListDelta resizes an array to Len and replaces the changed Items by index.
+type ListDelta struct {
+	Len   int                     `json:"len"`
+	Items map[int]json.RawMessage `json:"items,omitempty"`
+}
+
+// UndoStack stores the most recent snapshot in full and every older one as a
+// delta from the snapshot after it. It is persisted with the game.
+type UndoStack struct {
+	Top    snapshotFields `json:"top,omitempty"`
+	Deltas []Delta        `json:"deltas,omitempty"` // Deltas[i] turns snapshot i+1 into snapshot i
+}
+
+// Len returns the number of snapshots that can be undone.
+func (u *UndoStack) Len() int {
+	if u.Top == nil {
+		return 0
+	}
+	return len(u.Deltas) + 1
+}
+
+// Push records a snapshot, dropping the oldest ones beyond depth.
+func (u *UndoStack) Push(s snapshotFields, depth int) {
+	if u.Top != nil {
+		u.Deltas = append(u.Deltas, diffSnapshots(s, u.Top))
+	}
+	u.Top = s
+
+	if depth < 1 {
+		depth = 1
+	}
+	if extra := u.Len() - depth; extra > 0 {
+		u.Deltas = append([]Delta(nil), u.Deltas[extra:]...)
+	}
+}
+
+// Pop removes and returns the most recent snapshot.
+func (u *UndoStack) Pop() (snapshotFields, bool) {
+	if u.Top == nil {
+		return nil, false
+	}
+	top := u.Top
+	if n := len(u.Deltas); n > 0 {
+		u.Top = u.Deltas[n-1].apply(top)
+		u.Deltas = u.Deltas[:n-1]
+	} else {
+		u.Top = nil
+	}
+	return top, true
+}
+
+func encodeSnapshot(v any) (snapshotFields, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var fields snapshotFields
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func decodeSnapshot(fields snapshotFields, v any) error {
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// diffSnapshots returns the delta that turns from into to.
+func diffSnapshots(from, to snapshotFields) Delta {
+	var d Delta
+	for key, value := range to {
+		old, ok := from[key]
+		if ok && bytes.Equal(old, value) {
+			continue
+		}
+		if list, ok := diffLists(old, value); ok {
+			if d.Lists == nil {
+				d.Lists = make(map[string]ListDelta)
+			}
+			d.Lists[key] = list
+			continue
+		}
+		if d.Set == nil {
+			d.Set = make(map[string]json.RawMessage)
+		}
+		d.Set[key] = value
+	}
+	for key := range from {
+		if _, ok := to[key]; !ok {
+			d.Del = append(d.Del, key)
+		}
+	}
+	return d
+}
+
+// diffLists diffs two JSON arrays. It reports false if either is not an
+// array.
+func diffLists(from, to json.RawMessage) (ListDelta, bool) {
+	var fromItems, toItems []json.RawMessage
+	if json.Unmarshal(from, &fromItems) != nil || json.Unmarshal(to, &toItems) != nil {
+		return ListDelta{}, false
+	}
+	if fromItems == nil || toItems == nil {
+		// null and [] need a wholesale replacement to round-trip exactly
+		return ListDelta{}, false
+	}
+
+	list := ListDelta{Len: len(toItems)}
+	for i, item := range toItems {
+		if i < len(fromItems) && bytes.Equal(fromItems[i], item) {
+			continue
+		}
+		if list.Items == nil {
+			list.Items = make(map[int]json.RawMessage)
+		}
+		list.Items[i] = item
+	}
+	return list, true
+}
+
+// apply returns the snapshot the delta leads to, leaving s untouched.
+func (d Delta) apply(s snapshotFields) snapshotFields {
+	out := make(snapshotFields, len(s))
+	for key, value := range s {
+		out[key] = value
+	}
+	for _, key := range d.Del {
+		delete(out, key)
+	}
+	for key, value := range d.Set {
+		out[key] = value
+	}
+	for key, list := range d.Lists {
+		var items []json.RawMessage
+		json.Unmarshal(out[key], &items)
+
+		patched := make([]json.RawMessage, list.Len)
+		copy(patched, items)
+		for i, item := range list.Items {
+			if i < list.Len {
+				patched[i] = item
+			}
+		}
+		data, err := json.Marshal(patched)
+		if err != nil {
+			panic(fmt.Sprintf("model: patching %s: %v", key, err))
+		}
+		out[key] = data
+	}
+	return out
+}
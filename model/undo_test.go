@@ -0,0 +1,80 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func snapshot(t *testing.T, raw string) snapshotFields {
+	t.Helper()
+	var fields snapshotFields
+	if err := json.Unmarshal([]byte(raw), &fields); err != nil {
+		t.Fatal(err)
+	}
+	return fields
+}
+
+func TestUndoStackRoundTrip(t *testing.T) {
+	tests := []struct {
+		name      string
+		snapshots []string // Pushed in order
+		depth     int
+		want      int // How many come back out
+	}{
+		{"single", []string{`{"turn":1}`}, 10, 1},
+		{"field changed", []string{`{"turn":1,"phase":"Day"}`, `{"turn":2,"phase":"Day"}`}, 10, 2},
+		{"field added and removed", []string{`{"turn":1}`, `{"turn":1,"winner":"Good"}`, `{"turn":1}`}, 10, 3},
+		{"list grows and shrinks", []string{
+			`{"players":[{"id":1},{"id":2}]}`,
+			`{"players":[{"id":1},{"id":2},{"id":3}]}`,
+			`{"players":[{"id":1,"is_alive":false}]}`,
+			`{"players":[]}`,
+		}, 10, 4},
+		{"null and empty lists", []string{`{"events":null}`, `{"events":[]}`, `{"events":null}`}, 10, 3},
+		{"list replaced by scalar", []string{`{"bluffs":["Chef"]}`, `{"bluffs":"none"}`, `{"bluffs":["Chef","Monk"]}`}, 10, 3},
+		{"depth drops oldest", []string{`{"turn":1}`, `{"turn":2}`, `{"turn":3}`, `{"turn":4}`}, 2, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var u UndoStack
+			for _, raw := range tt.snapshots {
+				u.Push(snapshot(t, raw), tt.depth)
+			}
+			if u.Len() != tt.want {
+				t.Fatalf("Len() = %d, want %d", u.Len(), tt.want)
+			}
+
+			// Round-trip through a save file too
+			data, err := json.Marshal(u)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var loaded UndoStack
+			if err := json.Unmarshal(data, &loaded); err != nil {
+				t.Fatal(err)
+			}
+
+			for i := len(tt.snapshots) - 1; i >= len(tt.snapshots)-tt.want; i-- {
+				got, ok := loaded.Pop()
+				if !ok {
+					t.Fatalf("Pop() ran out at snapshot %d", i)
+				}
+				if want := snapshot(t, tt.snapshots[i]); !snapshotsEqual(got, want) {
+					t.Errorf("snapshot %d = %s, want %s", i, mustJSON(t, got), tt.snapshots[i])
+				}
+			}
+			if _, ok := loaded.Pop(); ok {
+				t.Error("Pop() returned more snapshots than were kept")
+			}
+		})
+	}
+}
+
+func mustJSON(t *testing.T, v any) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
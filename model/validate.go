@@ -0,0 +1,98 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Supported player counts
+const (
+	MinPlayers = 5
+	MaxPlayers = 15
+)
+
+var validRoleTypes = map[RoleType]bool{
+	Townsfolk: true,
+	Outsider:  true,
+	Minion:    true,
+	Demon:     true,
+	Traveler:  true,
+}
+
+var validActionTypes = map[ActionType]bool{
+	"":                 true, // Omitted in the JSON, treated as ActionNone
+	ActionNone:         true,
+	ActionSelectPlayer: true,
+	ActionSelectRole:   true,
+	ActionYesNo:        true,
+	ActionInfoToken:    true,
+}
+
+// ScriptError lists every problem found while validating a script.
+type ScriptError struct {
+	Script   string
+	Problems []string
+}
+
+func (e *ScriptError) Error() string {
+	return fmt.Sprintf("script %q has %d problem(s):\n  - %s",
+		e.Script, len(e.Problems), strings.Join(e.Problems, "\n  - "))
+}
+
+// Validate checks that a script can run a game of up to MaxPlayers players.
+// It reports all problems at once as a *ScriptError, or returns nil.
+func (s Script) Validate() error {
+	var problems []string
+	addProblem := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	seen := make(map[string]bool)
+	for i, r := range s.Roles {
+		name := r.Name
+		if name == "" {
+			name = fmt.Sprintf("role #%d", i+1)
+			addProblem("%s has no name", name)
+		} else if seen[name] {
+			addProblem("%s is listed more than once", name)
+		}
+		seen[r.Name] = true
+
+		if !validRoleTypes[r.Type] {
+			addProblem("%s has unknown type %q (expected Townsfolk, Outsider, Minion, Demon or Traveler)", name, r.Type)
+		}
+		if !validActionTypes[r.ActionType] {
+			addProblem("%s has unknown action_type %q (expected None, SelectPlayer, SelectRole, YesNo or InfoToken)", name, r.ActionType)
+		}
+		if r.SetupReversible && r.SetupModifier == nil {
+			addProblem("%s has setup_reversible but no setup_modifier to reverse", name)
+		}
+	}
+
+	checkOrder := func(list string, order []string) {
+		for _, name := range order {
+			if !seen[name] {
+				addProblem("%s lists %s, which is not one of the script's roles", list, name)
+			}
+		}
+	}
+	checkOrder("first_night", s.FirstNight)
+	checkOrder("other_night", s.OtherNight)
+
+	counts := CountBag(s.Roles)
+	need := BaseDistribution(MaxPlayers)
+	checkCount := func(t RoleType, have, want int) {
+		if have < want {
+			addProblem("%d %s roles needed for %d players (have %d)", want, t, MaxPlayers, have)
+		}
+	}
+	checkCount(Townsfolk, counts.Townsfolk, need.Townsfolk)
+	checkCount(Outsider, counts.Outsider, need.Outsider)
+	checkCount(Minion, counts.Minion, need.Minion)
+	checkCount(Demon, counts.Demon, need.Demon)
+
+	if len(problems) > 0 {
+		return &ScriptError{Script: s.Name, Problems: problems}
+	}
+	return nil
+}
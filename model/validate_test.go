@@ -0,0 +1,79 @@
+package model
+
+import (
+	"clocktower/data"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		file string
+		want []string // Every problem, in order
+	}{
+		{"bad_roles.json", []string{
+			"Imp is listed more than once",
+			"role #3 has no name",
+			`Chef has unknown type "Villager" (expected Townsfolk, Outsider, Minion, Demon or Traveler)`,
+			`Monk has unknown action_type "Protect" (expected None, SelectPlayer, SelectRole, YesNo or InfoToken)`,
+			"Godfather has setup_reversible but no setup_modifier to reverse",
+			"first_night lists Poisoner, which is not one of the script's roles",
+			"other_night lists Butler, which is not one of the script's roles",
+			"9 Townsfolk roles needed for 15 players (have 2)",
+			"2 Outsider roles needed for 15 players (have 0)",
+			"3 Minion roles needed for 15 players (have 1)",
+		}},
+		{"empty.json", []string{
+			"9 Townsfolk roles needed for 15 players (have 0)",
+			"2 Outsider roles needed for 15 players (have 0)",
+			"3 Minion roles needed for 15 players (have 0)",
+			"1 Demon roles needed for 15 players (have 0)",
+		}},
+		{"too_few_minions.json", []string{
+			"3 Minion roles needed for 15 players (have 2)",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			raw, err := os.ReadFile(filepath.Join("testdata", "validate", tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			script, err := ParseScript(raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = script.Validate()
+			var scriptErr *ScriptError
+			if !errors.As(err, &scriptErr) {
+				t.Fatalf("Validate() = %v, want a *ScriptError", err)
+			}
+			if scriptErr.Script != script.Name {
+				t.Errorf("error is for script %q, want %q", scriptErr.Script, script.Name)
+			}
+			if got := strings.Join(scriptErr.Problems, "\n"); got != strings.Join(tt.want, "\n") {
+				t.Errorf("problems:\n%s\nwant:\n%s", got, strings.Join(tt.want, "\n"))
+			}
+			if !strings.HasPrefix(err.Error(), `script "`+script.Name+`" has `) {
+				t.Errorf("Error() = %q", err)
+			}
+		})
+	}
+}
+
+func TestBundledScriptsAreValid(t *testing.T) {
+	files, err := fs.Glob(data.Scripts(), "*.json")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no bundled scripts: %v", err)
+	}
+	for _, file := range files {
+		if err := bundledScript(t, file).Validate(); err != nil {
+			t.Errorf("%s: %v", file, err)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package model
+
+import "fmt"
+
+// Vote records a single player's hand during a nomination.
+type Vote struct {
+	PlayerID  int  `json:"player_id"`
+	Raised    bool `json:"raised"`
+	GhostVote bool `json:"ghost_vote"` // Dead voter spent their ghost vote on this hand
+}
+
+type Nomination struct {
+	NominatorID int    `json:"nominator_id"`
+	NomineeID   int    `json:"nominee_id"`
+	Votes       []Vote `json:"votes"`
+	Threshold   int    `json:"threshold"` // Votes needed to put the nominee on the block
+	Cursor      int    `json:"cursor"`    // Position in the clockwise vote order
+	Open        bool   `json:"open"`
+}
+
+// VoteCount returns the number of raised hands.
+func (n *Nomination) VoteCount() int {
+	count := 0
+	for _, v := range n.Votes {
+		if v.Raised {
+			count++
+		}
+	}
+	return count
+}
+
+// Logic: Nominations
+
+func (g *Game) VoteThreshold() int {
+	alive := 0
+	for _, p := range g.Players {
+		if p != nil && p.IsAlive {
+			alive++
+		}
+	}
+	// Half of the living, rounded up
+	return (alive + 1) / 2
+}
+
+func (g *Game) CanVote(p *Player) bool {
+	return p.IsAlive || !p.UsedGhostVote
+}
+
+func (g *Game) CurrentNomination() *Nomination {
+	if len(g.Nominations) == 0 {
+		return nil
+	}
+	last := &g.Nominations[len(g.Nominations)-1]
+	if !last.Open {
+		return nil
+	}
+	return last
+}
+
+func (g *Game) nominate(nominatorID, nomineeID int) error {
+	if g.Phase != PhaseDay {
+		return fmt.Errorf("nominations only happen during the day")
+	}
+	if g.CurrentNomination() != nil {
+		return fmt.Errorf("a vote is already in progress")
+	}
+
+	nominator := g.GetPlayerByID(nominatorID)
+	nominee := g.GetPlayerByID(nomineeID)
+	if nominator == nil || nominee == nil {
+		return fmt.Errorf("invalid player")
+	}
+	if !nominator.IsAlive {
+		return fmt.Errorf("%s is dead and cannot nominate", nominator.Name)
+	}
+
+	// Each player may nominate once, and be nominated once, per day
+	for _, n := range g.Nominations {
+		if n.NominatorID == nominatorID {
+			return fmt.Errorf("%s has already nominated today", nominator.Name)
+		}
+		if n.NomineeID == nomineeID {
+			return fmt.Errorf("%s has already been nominated today", nominee.Name)
+		}
+	}
+
+	g.Nominations = append(g.Nominations, Nomination{
+		NominatorID: nominatorID,
+		NomineeID:   nomineeID,
+		Threshold:   g.VoteThreshold(),
+		Open:        true,
+	})
+	return nil
+}
+
+// VoteOrder returns the players in voting order: clockwise starting with the
+// player after the nominee, ending with the nominee.
+func (g *Game) VoteOrder(nomineeID int) []*Player {
+	start := g.IndexOfPlayer(nomineeID)
+	if start == -1 {
+		return nil
+	}
+	n := len(g.Players)
+	order := make([]*Player, 0, n)
+	for i := 1; i <= n; i++ {
+		order = append(order, g.Players[(start+i)%n])
+	}
+	return order
+}
+
+// NextVoter returns the next player in the vote order who is able to vote,
+// or nil if the vote has gone all the way around the circle.
+func (g *Game) NextVoter() *Player {
+	nom := g.CurrentNomination()
+	if nom == nil {
+		return nil
+	}
+	order := g.VoteOrder(nom.NomineeID)
+	for i := nom.Cursor; i < len(order); i++ {
+		if g.CanVote(order[i]) {
+			return order[i]
+		}
+	}
+	return nil
+}
+
+func (g *Game) castVote(raised bool) (Vote, error) {
+	nom := g.CurrentNomination()
+	if nom == nil {
+		return Vote{}, fmt.Errorf("no vote in progress")
+	}
+	voter := g.NextVoter()
+	if voter == nil {
+		return Vote{}, fmt.Errorf("everyone has voted")
+	}
+
+	// Dead players without a ghost vote are skipped automatically
+	order := g.VoteOrder(nom.NomineeID)
+	for nom.Cursor < len(order) && order[nom.Cursor] != voter {
+		skipped := order[nom.Cursor]
+		g.record(Event{Kind: EventVoteSkip, Tag: "Day", Actor: skipped.ID, Targets: []int{nom.NomineeID}},
+			"%s cannot vote (ghost vote used)", skipped.Name)
+		nom.Cursor++
+	}
+	nom.Cursor++
+
+	vote := Vote{PlayerID: voter.ID, Raised: raised}
+	if raised && !voter.IsAlive {
+		voter.UsedGhostVote = true
+		vote.GhostVote = true
+	}
+	nom.Votes = append(nom.Votes, vote)
+	return vote, nil
+}
+
+// cancelNomination withdraws the open nomination as if it was never made,
+// giving back any ghost votes spent on it.
+func (g *Game) cancelNomination() (Nomination, error) {
+	nom := g.CurrentNomination()
+	if nom == nil {
+		return Nomination{}, fmt.Errorf("no vote in progress")
+	}
+	for _, v := range nom.Votes {
+		if v.GhostVote {
+			g.GetPlayerByID(v.PlayerID).UsedGhostVote = false
+		}
+	}
+	cancelled := *nom
+	g.Nominations = g.Nominations[:len(g.Nominations)-1]
+	return cancelled, nil
+}
+
+func (g *Game) closeNomination() error {
+	nom := g.CurrentNomination()
+	if nom == nil {
+		return fmt.Errorf("no vote in progress")
+	}
+	nom.Open = false
+
+	nominee := g.GetPlayerByID(nom.NomineeID)
+	result := Event{Kind: EventVoteResult, Tag: "Day", Targets: []int{nominee.ID}, Result: fmt.Sprint(nom.VoteCount())}
+	g.record(result, "%s received %d votes (%d needed)", nominee.Name, nom.VoteCount(), nom.Threshold)
+
+	if block, votes := g.OnTheBlock(); block != nil {
+		result.Targets = []int{block.ID}
+		result.Result = fmt.Sprint(votes)
+		g.record(result, "%s is on the block with %d votes", block.Name, votes)
+	} else if votes > 0 {
+		result.Targets = nil
+		result.Result = fmt.Sprint(votes)
+		g.record(result, "Tied on %d votes, nobody is on the block", votes)
+	}
+	return nil
+}
+
+// OnTheBlock returns the player about to be executed and their vote count.
+// A tie for the highest count means nobody is on the block; the tied count is
+// still returned so callers can report it.
+func (g *Game) OnTheBlock() (*Player, int) {
+	var block *Player
+	best := 0
+	tied := false
+	for _, n := range g.Nominations {
+		if n.Open {
+			continue
+		}
+		votes := n.VoteCount()
+		if votes < n.Threshold || votes < best {
+			continue
+		}
+		if votes == best {
+			tied = true
+			continue
+		}
+		best = votes
+		block = g.GetPlayerByID(n.NomineeID)
+		tied = false
+	}
+	if tied {
+		return nil, best
+	}
+	return block, best
+}
+
+func (g *Game) clearNominations() {
+	g.Nominations = nil
+}
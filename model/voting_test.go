@@ -0,0 +1,38 @@
+package model
+
+import "testing"
+
+func TestCancelNomination(t *testing.T) {
+	g := newTestGame(t, "Imp", "Poisoner", "Chef", "Empath", "Monk")
+	ghost := g.Players[4]
+	if err := g.SetPlayerAlive(4, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := g.Nominate(1, 3); err != nil {
+		t.Fatal(err)
+	}
+	// Vote order starts after the nominee: Empath, then the dead Monk
+	g.CastVote(false)
+	g.CastVote(true)
+	if !ghost.UsedGhostVote {
+		t.Fatal("dead voter did not spend their ghost vote")
+	}
+
+	if err := g.CancelNomination(); err != nil {
+		t.Fatal(err)
+	}
+	if g.CurrentNomination() != nil || len(g.Nominations) != 0 {
+		t.Errorf("nominations = %+v, want none", g.Nominations)
+	}
+	if ghost.UsedGhostVote {
+		t.Error("ghost vote was not given back")
+	}
+	if err := g.CancelNomination(); err == nil {
+		t.Error("cancelling with no vote in progress succeeded")
+	}
+	// The same pair can nominate again
+	if err := g.Nominate(1, 3); err != nil {
+		t.Errorf("renominating: %v", err)
+	}
+}
@@ -2,11 +2,13 @@ package tui
 
 import (
 	"clocktower/model"
+	"fmt"
 	"math/rand"
-	"os"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
 )
 
 type MainModel struct {
@@ -14,6 +16,13 @@ type MainModel struct {
 	setup     *SetupModel
 	grimoire  *GrimoireModel
 	viewState ViewState
+	scripts   []model.ScriptSource
+	// Startup save picker
+	picker *huh.Form
+	err    error
+	// Last terminal size, handed to the grimoire when it is created
+	width  int
+	height int
 }
 
 type ViewState int
@@ -21,35 +30,28 @@ type ViewState int
 const (
 	ViewSetup ViewState = iota
 	ViewGrimoire
+	ViewPicker
 )
 
-func NewMainModel() *MainModel {
-	g := model.NewGame()
-
-	// Try loading existing state
-	if err := g.LoadState(); err == nil {
-		// If loaded, go straight to Grimoire
-		return &MainModel{
-			game:      g,
-			grimoire:  NewGrimoireModel(g),
-			viewState: ViewGrimoire,
-		}
-	}
-
-	return &MainModel{
-		game:      g,
-		setup:     NewSetupModel(g),
-		viewState: ViewSetup,
-	}
+// NewMainModel offers the saved games to resume if there are any, otherwise
+// it starts the setup wizard with the given scripts to choose from.
+func NewMainModel(scripts []model.ScriptSource) *MainModel {
+	m := &MainModel{scripts: scripts}
+	m.openStart()
+	return m
 }
 
 func (m *MainModel) Init() tea.Cmd {
-	if m.viewState == ViewSetup {
+	switch m.viewState {
+	case ViewSetup:
 		return m.setup.Init()
+	case ViewPicker:
+		return m.picker.Init()
 	}
 	return nil
 }
 
+// ResetGameMsg archives the current game and returns to the start screen.
 type ResetGameMsg struct{}
 
 func (m *MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -58,14 +60,27 @@ func (m *MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.String() == "ctrl+c" {
 			return m, tea.Quit
 		}
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
 	case ResetGameMsg:
-		os.Remove("game_state.json")
-		return m, tea.Quit
+		if err := m.game.ArchiveState(); err != nil {
+			m.err = err
+		}
+		return m, m.openStart()
 	}
 
 	var cmd tea.Cmd
 
 	switch m.viewState {
+	case ViewPicker:
+		form, formCmd := m.picker.Update(msg)
+		if f, ok := form.(*huh.Form); ok {
+			m.picker = f
+		}
+		cmd = formCmd
+		if m.picker.State == huh.StateCompleted {
+			cmd = m.pickSave(m.picker.GetString("slot"))
+		}
 	case ViewSetup:
 		_, cmd = m.setup.Update(msg)
 		if m.setup.finished {
@@ -84,66 +99,87 @@ func (m *MainModel) View() string {
 		return m.setup.View()
 	case ViewGrimoire:
 		return m.grimoire.View()
+	case ViewPicker:
+		if m.err != nil {
+			return lipgloss.NewStyle().Foreground(ColorError).Render(m.err.Error()) + "\n\n" + m.picker.View()
+		}
+		return m.picker.View()
 	}
 	return "Unknown State"
 }
 
 func (m *MainModel) transitionToGame() {
-	// Finalize setup
-	// Assign roles randomly if not set (simple implementation for now)
-	if len(m.game.Players) > 0 && m.game.Players[0].Role.Name == "" {
-		m.assignRandomRoles()
+	// Propose Demon bluffs now that every character (and the Drunk's) is known
+	if len(m.game.DemonBluffs) == 0 {
+		r := rand.New(rand.NewSource(time.Now().UnixNano()))
+		m.game.DemonBluffs = m.game.ProposeBluffs(r)
+	}
+
+	if m.game.Name == "" {
+		m.game.Name = fmt.Sprintf("%s %s", m.game.Script.Name, time.Now().Format("2006-01-02 15:04"))
 	}
+	m.game.AssignSlot()
+	// Everything from here on is recorded as events
+	m.game.Begin()
 
 	// Initialize Grimoire
 	m.grimoire = NewGrimoireModel(m.game)
+	m.grimoire.setSize(m.width, m.height)
 	m.viewState = ViewGrimoire
 	m.game.SaveState()
 }
 
-func (m *MainModel) assignRandomRoles() {
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-
-	// Separate roles by type
-	var townsfolk, outsiders, minions, demons []model.Role
-	for _, role := range m.game.Script.Roles {
-		switch role.Type {
-		case model.Townsfolk:
-			townsfolk = append(townsfolk, role)
-		case model.Outsider:
-			outsiders = append(outsiders, role)
-		case model.Minion:
-			minions = append(minions, role)
-		case model.Demon:
-			demons = append(demons, role)
-		}
+// openStart shows the save picker when there are saved games, otherwise the
+// setup wizard for a new game.
+func (m *MainModel) openStart() tea.Cmd {
+	saves, err := model.ListSaves()
+	if err != nil {
+		m.err = err
+	}
+	if len(saves) == 0 {
+		return m.newGame()
 	}
 
-	// Shuffle buckets
-	shuffle := func(roles []model.Role) {
-		r.Shuffle(len(roles), func(i, j int) { roles[i], roles[j] = roles[j], roles[i] })
+	options := []huh.Option[string]{huh.NewOption("New game", "")}
+	for _, save := range saves {
+		options = append(options, huh.NewOption(save.Label(), save.Slot))
 	}
-	shuffle(townsfolk)
-	shuffle(outsiders)
-	shuffle(minions)
-	shuffle(demons)
-
-	// Get distribution counts
-	tfCount, outCount, minCount, demCount := model.GetDistribution(len(m.game.Players))
-
-	// Select roles
-	var selectedRoles []model.Role
-	selectedRoles = append(selectedRoles, townsfolk[:tfCount]...)
-	selectedRoles = append(selectedRoles, outsiders[:outCount]...)
-	selectedRoles = append(selectedRoles, minions[:minCount]...)
-	selectedRoles = append(selectedRoles, demons[:demCount]...)
-
-	// Shuffle final selection so they are distributed randomly to players
-	shuffle(selectedRoles)
-
-	for i, p := range m.game.Players {
-		if i < len(selectedRoles) {
-			p.Role = selectedRoles[i]
-		}
+	m.picker = huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Key("slot").
+				Options(options...).
+				Title("Resume a game"),
+		),
+	)
+	m.viewState = ViewPicker
+	return m.picker.Init()
+}
+
+func (m *MainModel) newGame() tea.Cmd {
+	m.game = model.NewGame()
+	m.setup = NewSetupModel(m.game, m.scripts)
+	m.grimoire = nil
+	m.viewState = ViewSetup
+	return m.setup.Init()
+}
+
+// pickSave resumes the chosen game, or starts a new one for the empty slot.
+func (m *MainModel) pickSave(slot string) tea.Cmd {
+	if slot == "" {
+		m.err = nil
+		return m.newGame()
+	}
+
+	g := model.NewGame()
+	if err := g.LoadState(slot); err != nil {
+		m.err = fmt.Errorf("could not load %s: %w", slot, err)
+		return m.openStart()
 	}
+	m.err = nil
+	m.game = g
+	m.grimoire = NewGrimoireModel(g)
+	m.grimoire.setSize(m.width, m.height)
+	m.viewState = ViewGrimoire
+	return nil
 }
@@ -3,8 +3,11 @@ package tui
 import (
 	"clocktower/model"
 	"fmt"
+	"math/rand"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -24,6 +27,16 @@ const (
 	StateEdit
 	StateEditRoleSelect
 	StateRoleInfo
+	StateNominateNominator
+	StateNominateNominee
+	StateVoting
+	StateGameOver
+	StateNightStarPass
+	StateBluffSelect
+	StateLog
+	StateReplay
+	StateReminders
+	StateNightShowRole
 )
 
 type GrimoireModel struct {
@@ -32,6 +45,7 @@ type GrimoireModel struct {
 	state      GrimoireState
 	nightStep  int
 	nightQueue []string // List of Role Names to wake up
+	chefAdjust int      // Storyteller's change to the Chef's suggested number
 	// Selection state
 	selectCursor int
 	selectedPID  int // Player ID being targeted
@@ -41,13 +55,43 @@ type GrimoireModel struct {
 	roleList   []string // Filtered list of roles to select
 	roleCursor int
 	infoRole   string // Selected role for reveal
+	// Nomination state
+	nominatorIdx int
+	statusMsg    string // Last error or notice shown on the overview
+	// Ctrl+n was pressed once on an unfinished game
+	confirmArchive bool
+	// Game over screen has been dismissed to review the grimoire
+	gameOverSeen bool
+	// Demon bluff editing
+	bluffPicks  map[string]bool
+	bluffReturn GrimoireState
+	// Game log panel
+	logView   viewport.Model
+	logFilter logFilter
+	// Replay of past phases
+	replayChapters []model.Chapter
+	replayIndex    int
+	replayGame     *model.Game // State at the end of the shown chapter
+	replayErr      error
+	replayView     viewport.Model
+	// Reminder token editing for the player under the cursor
+	reminderCursor int
+	// Draw the players around a circle instead of the table
+	townSquare bool
+	// Terminal size, zero until the first WindowSizeMsg
+	width  int
+	height int
 }
 
 func NewGrimoireModel(game *model.Game) *GrimoireModel {
-	return &GrimoireModel{
+	m := &GrimoireModel{
 		game:  game,
 		state: StateOverview,
 	}
+	if game.Winner != "" {
+		m.state = StateGameOver
+	}
+	return m
 }
 
 func (m *GrimoireModel) Init() tea.Cmd {
@@ -61,43 +105,95 @@ func (m *GrimoireModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.setSize(msg.Width, msg.Height)
 	case tea.KeyMsg:
-		// Dispatch based on state
-		switch m.state {
-		case StateNightWalk:
-			return m.updateNightWalk(msg)
-		case StateNightSelect:
-			return m.updateNightSelect(msg)
-		case StateNightInfoSelect1:
-			return m.updateNightInfo1(msg)
-		case StateNightInfoSelect2:
-			return m.updateNightInfo2(msg)
-		case StateNightInfoRole:
-			return m.updateNightInfoRole(msg)
-		case StateNightInfoReveal:
-			return m.updateNightInfoReveal(msg)
-		case StateNightFortuneRedHerring:
-			return m.updateNightFortuneRedHerring(msg)
-		case StateNightFortuneReveal:
-			return m.updateNightFortuneReveal(msg)
-		case StateEdit:
-			return m.updateEdit(msg)
-		case StateEditRoleSelect:
-			return m.updateEditRoleSelect(msg)
-		case StateRoleInfo:
-			return m.updateRoleInfo(msg)
-		default:
-			return m.updateOverview(msg)
+		// The layout can be switched on any screen that shows the players
+		if msg.String() == "tab" {
+			m.townSquare = !m.townSquare
+			return m, nil
 		}
+		next, cmd := m.dispatchKey(msg)
+
+		// Any action may have ended the game
+		if m.game.Winner == "" {
+			m.gameOverSeen = false
+		} else if !m.gameOverSeen {
+			m.state = StateGameOver
+		}
+		return next, cmd
 	}
 	return m, nil
 }
 
+func (m *GrimoireModel) setSize(width, height int) {
+	m.width, m.height = width, height
+	switch m.state {
+	case StateLog:
+		m.logView.Width, m.logView.Height = m.logSize()
+	case StateReplay:
+		m.replayView.Width, m.replayView.Height = m.replaySize()
+	}
+}
+
+func (m *GrimoireModel) dispatchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Dispatch based on state
+	switch m.state {
+	case StateNightWalk:
+		return m.updateNightWalk(msg)
+	case StateNightSelect:
+		return m.updateNightSelect(msg)
+	case StateNightInfoSelect1:
+		return m.updateNightInfo1(msg)
+	case StateNightInfoSelect2:
+		return m.updateNightInfo2(msg)
+	case StateNightInfoRole:
+		return m.updateNightInfoRole(msg)
+	case StateNightInfoReveal:
+		return m.updateNightInfoReveal(msg)
+	case StateNightFortuneRedHerring:
+		return m.updateNightFortuneRedHerring(msg)
+	case StateNightFortuneReveal:
+		return m.updateNightFortuneReveal(msg)
+	case StateEdit:
+		return m.updateEdit(msg)
+	case StateEditRoleSelect:
+		return m.updateEditRoleSelect(msg)
+	case StateRoleInfo:
+		return m.updateRoleInfo(msg)
+	case StateNominateNominator:
+		return m.updateNominateNominator(msg)
+	case StateNominateNominee:
+		return m.updateNominateNominee(msg)
+	case StateVoting:
+		return m.updateVoting(msg)
+	case StateGameOver:
+		return m.updateGameOver(msg)
+	case StateNightStarPass:
+		return m.updateNightStarPass(msg)
+	case StateBluffSelect:
+		return m.updateBluffSelect(msg)
+	case StateLog:
+		return m.updateLog(msg)
+	case StateReplay:
+		return m.updateReplay(msg)
+	case StateReminders:
+		return m.updateReminders(msg)
+	case StateNightShowRole:
+		return m.updateNightShowRole(msg)
+	default:
+		return m.updateOverview(msg)
+	}
+}
+
 func (m *GrimoireModel) updateOverview(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Ensure cursor is valid
 	if m.cursor >= len(m.game.Players) {
 		m.cursor = len(m.game.Players) - 1
 	}
+	m.statusMsg = ""
+	confirmArchive := m.confirmArchive
+	m.confirmArchive = false
 
 	switch msg.String() {
 	case "up", "k":
@@ -110,58 +206,77 @@ func (m *GrimoireModel) updateOverview(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 	case "enter":
 		if len(m.game.Players) > 0 {
-			m.game.Players[m.cursor].IsAlive = !m.game.Players[m.cursor].IsAlive
-			m.game.SaveState()
+			m.game.SetPlayerAlive(m.cursor, !m.game.Players[m.cursor].IsAlive)
 		}
 	case "n":
-		if m.game.Phase == model.PhaseDay {
-			// Start Night Sequence
-			m.game.Phase = model.PhaseNight
-			m.game.Turn++
+		// Ending the day resolves the execution; then start the Night Sequence
+		m.game.AdvancePhase()
+		if m.game.Phase == model.PhaseNight {
 			m.startNight()
-		} else {
-			m.game.Phase = model.PhaseDay
 		}
-		m.game.SaveState()
 	case "q":
 		return m, tea.Quit
 	case "ctrl+n":
+		// An archived game no longer shows up in the resume picker, so ask
+		// before archiving one that is still in progress
+		if m.game.Winner == "" && !confirmArchive {
+			m.confirmArchive = true
+			m.statusMsg = "This game is still in progress. Press Ctrl+n again to archive it and start a new one."
+			return m, nil
+		}
 		return m, func() tea.Msg { return ResetGameMsg{} }
 	case "u":
-		m.game.Undo()
+		if err := m.game.Undo(); err != nil {
+			m.statusMsg = err.Error()
+		}
+	case "ctrl+r":
+		if err := m.game.Redo(); err != nil {
+			m.statusMsg = err.Error()
+		}
 	case "e":
 		m.state = StateEdit
+	case "v":
+		if m.game.Phase != model.PhaseDay {
+			m.statusMsg = "Nominations only happen during the day"
+			return m, nil
+		}
+		m.state = StateNominateNominator
+		m.selectCursor = m.cursor
 	case "i":
 		m.state = StateRoleInfo
+	case "b":
+		m.openBluffSelect(StateOverview)
+	case "L":
+		m.openLog()
+	case "T":
+		m.openReplay()
+	case "t":
+		m.reminderCursor = 0
+		m.state = StateReminders
 	case "g":
 		// Toggle Ghost Vote (only if dead)
 		if m.cursor < len(m.game.Players) {
 			p := m.game.Players[m.cursor]
 			if !p.IsAlive {
-				p.UsedGhostVote = !p.UsedGhostVote
-				m.game.SaveState()
+				m.game.SetGhostVote(m.cursor, !p.UsedGhostVote)
 			}
 		}
 	case "R":
 		// Toggle Registration Override
 		// Cycle: "" -> "Townsfolk" -> "Outsider" -> "Minion" -> "Demon" -> ""
 		if m.cursor < len(m.game.Players) {
-			p := m.game.Players[m.cursor]
-			switch p.RegistrationOverride {
+			var next string
+			switch m.game.Players[m.cursor].RegistrationOverride {
 			case "":
-				p.RegistrationOverride = "Townsfolk"
+				next = "Townsfolk"
 			case "Townsfolk":
-				p.RegistrationOverride = "Outsider"
+				next = "Outsider"
 			case "Outsider":
-				p.RegistrationOverride = "Minion"
+				next = "Minion"
 			case "Minion":
-				p.RegistrationOverride = "Demon"
-			case "Demon":
-				p.RegistrationOverride = ""
-			default:
-				p.RegistrationOverride = ""
+				next = "Demon"
 			}
-			m.game.SaveState()
+			m.game.SetRegistration(m.cursor, next)
 		}
 	}
 	return m, nil
@@ -183,13 +298,11 @@ func (m *GrimoireModel) updateEdit(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.cursor > 0 {
 			m.game.SwapPlayers(m.cursor, m.cursor-1)
 			m.cursor--
-			m.game.SaveState()
 		}
 	case "J": // Shift+j: Move Down
 		if m.cursor < len(m.game.Players)-1 {
 			m.game.SwapPlayers(m.cursor, m.cursor+1)
 			m.cursor++
-			m.game.SaveState()
 		}
 	case "enter", "r":
 		m.state = StateEditRoleSelect
@@ -213,7 +326,6 @@ func (m *GrimoireModel) updateEditRoleSelect(msg tea.KeyMsg) (tea.Model, tea.Cmd
 	case "enter":
 		selectedRole := m.roleList[m.roleCursor]
 		m.game.SetPlayerRole(m.cursor, selectedRole)
-		m.game.SaveState()
 		m.state = StateEdit
 	case "esc":
 		m.state = StateEdit
@@ -229,34 +341,167 @@ func (m *GrimoireModel) updateRoleInfo(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-func (m *GrimoireModel) startNight() {
-	// Reset transient night flags (poison/protection) at start of night
-	m.game.ResetNightChanges()
+func (m *GrimoireModel) updateNominateNominator(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if m.selectCursor > 0 {
+			m.selectCursor--
+		}
+	case "down", "j":
+		if m.selectCursor < len(m.game.Players)-1 {
+			m.selectCursor++
+		}
+	case "enter":
+		m.nominatorIdx = m.selectCursor
+		m.state = StateNominateNominee
+	case "esc":
+		m.state = StateOverview
+	}
+	return m, nil
+}
 
-	m.state = StateNightWalk
-	m.nightStep = 0
+func (m *GrimoireModel) updateNominateNominee(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if m.selectCursor > 0 {
+			m.selectCursor--
+		}
+	case "down", "j":
+		if m.selectCursor < len(m.game.Players)-1 {
+			m.selectCursor++
+		}
+	case "enter":
+		nominator := m.game.Players[m.nominatorIdx]
+		nominee := m.game.Players[m.selectCursor]
+		if err := m.game.Nominate(nominator.ID, nominee.ID); err != nil {
+			m.statusMsg = err.Error()
+			m.state = StateOverview
+			return m, nil
+		}
+		m.state = StateVoting
+		m.finishVoteIfDone()
+	case "esc":
+		m.state = StateNominateNominator
+	}
+	return m, nil
+}
 
-	// Determine which list to use
-	list := m.game.Script.OtherNight
+func (m *GrimoireModel) updateVoting(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "enter":
+		m.game.CastVote(true)
+	case "n", "right", "l":
+		m.game.CastVote(false)
+	case "u":
+		// Takes back the last vote, or the nomination itself
+		if err := m.game.Undo(); err != nil {
+			m.statusMsg = err.Error()
+		}
+	case "esc":
+		if err := m.game.CancelNomination(); err != nil {
+			m.statusMsg = err.Error()
+		}
+	default:
+		return m, nil
+	}
+	m.finishVoteIfDone()
+	return m, nil
+}
 
-	// If Turn is 1 (First Night), use FirstNight list
-	// Assuming Turn starts at 0 or 1. Let's make sure we increment it.
-	if m.game.Turn <= 1 {
-		list = m.game.Script.FirstNight
+// finishVoteIfDone returns to the overview once the nomination has closed
+// after the last eligible voter.
+func (m *GrimoireModel) finishVoteIfDone() {
+	if m.game.CurrentNomination() == nil {
+		m.state = StateOverview
 	}
+}
 
-	// Filter queue: Only include roles that are actually in play
-	var activeQueue []string
-	for _, roleName := range list {
-		// Check if any player has this role
-		for _, p := range m.game.Players {
-			if p != nil && p.Role.Name == roleName {
-				activeQueue = append(activeQueue, roleName)
-				break
+func (m *GrimoireModel) updateGameOver(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "enter":
+		// Review the grimoire; the banner stays on the overview
+		m.gameOverSeen = true
+		m.state = StateOverview
+	case "u":
+		if err := m.game.Undo(); err != nil {
+			m.statusMsg = err.Error()
+		}
+		m.state = StateOverview
+	case "r":
+		m.gameOverSeen = true
+		m.openReplay()
+	case "q":
+		return m, tea.Quit
+	case "ctrl+n":
+		return m, func() tea.Msg { return ResetGameMsg{} }
+	}
+	return m, nil
+}
+
+func (m *GrimoireModel) openBluffSelect(returnTo GrimoireState) {
+	m.roleList = nil
+	for _, r := range m.game.BluffCandidates() {
+		m.roleList = append(m.roleList, r.Name)
+	}
+	m.roleCursor = 0
+	m.bluffPicks = make(map[string]bool)
+	for _, b := range m.game.DemonBluffs {
+		m.bluffPicks[b] = true
+	}
+	m.bluffReturn = returnTo
+	m.state = StateBluffSelect
+}
+
+func (m *GrimoireModel) updateBluffSelect(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if m.roleCursor > 0 {
+			m.roleCursor--
+		}
+	case "down", "j":
+		if m.roleCursor < len(m.roleList)-1 {
+			m.roleCursor++
+		}
+	case " ", "x":
+		if len(m.roleList) == 0 {
+			return m, nil
+		}
+		name := m.roleList[m.roleCursor]
+		if m.bluffPicks[name] {
+			delete(m.bluffPicks, name)
+		} else if len(m.bluffPicks) < 3 {
+			m.bluffPicks[name] = true
+		}
+	case "r":
+		// Re-roll a fresh proposal
+		m.bluffPicks = make(map[string]bool)
+		for _, b := range m.game.ProposeBluffs(rand.New(rand.NewSource(time.Now().UnixNano()))) {
+			m.bluffPicks[b] = true
+		}
+	case "enter":
+		// Keep script order for a stable display
+		var bluffs []string
+		for _, name := range m.roleList {
+			if m.bluffPicks[name] {
+				bluffs = append(bluffs, name)
 			}
 		}
+		if err := m.game.SetDemonBluffs(bluffs); err != nil {
+			m.statusMsg = err.Error()
+		}
+		m.state = m.bluffReturn
+	case "esc":
+		m.state = m.bluffReturn
 	}
-	m.nightQueue = activeQueue
+	return m, nil
+}
+
+func (m *GrimoireModel) startNight() {
+	m.state = StateNightWalk
+	m.nightStep = 0
+	m.chefAdjust = 0
+
+	m.nightQueue = m.game.BuildNightQueue()
 }
 
 func (m *GrimoireModel) updateNightWalk(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
@@ -270,13 +515,48 @@ func (m *GrimoireModel) updateNightWalk(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 		// Check if current role has an action that requires selection
 		roleName := m.nightQueue[m.nightStep]
+
+		// Evil team info steps only need logging
+		switch roleName {
+		case model.MinionInfoStep:
+			m.game.ResolveMinionInfo()
+			m.nextStep()
+			return m, nil
+		case model.DemonInfoStep:
+			m.game.ResolveDemonInfo()
+			m.nextStep()
+			return m, nil
+		}
+
 		// Find player role
 		var currentRole model.Role
-		for _, p := range m.game.Players {
-			if p.Role.Name == roleName {
-				currentRole = p.Role
-				break
+		if p := m.game.FindActor(roleName); p != nil {
+			currentRole = p.ActingRole()
+		}
+
+		actor := m.game.FindActor(roleName)
+		switch currentRole.Name {
+		case "Chef":
+			m.game.ResolveChef(actor, m.chefNumber(actor))
+			m.nextStep()
+			return m, nil
+		case "Undertaker":
+			// Does not wake if dead or if nobody was executed
+			if executed := m.game.ExecutedToday(); executed != nil && actor.IsAlive {
+				m.openShowRole(executed)
+			} else {
+				m.nextStep()
+			}
+			return m, nil
+		case "Ravenkeeper":
+			// Only wakes on the night they die
+			if m.game.DiedTonight(actor) {
+				m.state = StateNightSelect
+				m.selectCursor = 0
+			} else {
+				m.nextStep()
 			}
+			return m, nil
 		}
 
 		// If action required, go to selection
@@ -316,6 +596,18 @@ func (m *GrimoireModel) updateNightWalk(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "right", "l":
 		m.nextStep()
+	case "+", "=", "-":
+		if m.nightStep < len(m.nightQueue) && m.nightQueue[m.nightStep] == "Chef" {
+			if msg.String() == "-" {
+				m.chefAdjust--
+			} else {
+				m.chefAdjust++
+			}
+		}
+	case "b":
+		if m.nightStep < len(m.nightQueue) && m.nightQueue[m.nightStep] == model.DemonInfoStep {
+			m.openBluffSelect(StateNightWalk)
+		}
 	case "f":
 		// Feature: Set Red Herring for Fortune Teller
 		// Only valid if current role is Fortune Teller
@@ -335,8 +627,23 @@ func (m *GrimoireModel) updateNightWalk(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// chefNumber is the number to show the Chef: the truth, or a believable false
+// number when they are drunk or poisoned, as adjusted by the storyteller.
+func (m *GrimoireModel) chefNumber(chef *model.Player) int {
+	n := m.game.ChefCount()
+	if chef != nil && chef.IsMalfunctioning() {
+		n = model.ChefFalseCount(n)
+	}
+	n += m.chefAdjust
+	if n < 0 {
+		n = 0
+	}
+	return n
+}
+
 func (m *GrimoireModel) nextStep() {
 	m.nightStep++
+	m.chefAdjust = 0
 	if m.nightStep >= len(m.nightQueue) {
 		m.state = StateOverview
 	}
@@ -357,12 +664,23 @@ func (m *GrimoireModel) updateNightSelect(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		target := m.game.Players[m.selectCursor]
 		actorName := m.nightQueue[m.nightStep]
 
-		// Execute Logic
-		resultMsg := m.game.ResolveNightAction(actorName, target)
+		// Imp targeting themself: storyteller chooses who catches the star
+		actor := m.game.FindActor(actorName)
+		if actorName == "Imp" && actor == target && !actor.IsMalfunctioning() &&
+			len(m.game.StarPassCandidates()) > 1 {
+			m.state = StateNightStarPass
+			m.selectCursor = m.game.IndexOfPlayer(m.game.DefaultStarPassRecipient().ID)
+			return m, nil
+		}
+
+		// The Ravenkeeper learns the character of their choice
+		if actorName == "Ravenkeeper" {
+			m.openShowRole(target)
+			return m, nil
+		}
 
-		// Log action
-		m.game.Log = append(m.game.Log, fmt.Sprintf("[Night] %s", resultMsg))
-		m.game.SaveState()
+		// Execute and log the action
+		m.game.ResolveNightAction(actorName, target)
 
 		// Return to walk and advance
 		m.state = StateNightWalk
@@ -375,6 +693,78 @@ func (m *GrimoireModel) updateNightSelect(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// openShowRole picks the character to show the Undertaker or Ravenkeeper for
+// a player, starting from the suggested one.
+func (m *GrimoireModel) openShowRole(target *model.Player) {
+	m.selectedPID = target.ID
+	m.prepareAllRolesList()
+	actor := m.game.FindActor(m.nightQueue[m.nightStep])
+	suggested := m.game.SuggestedCharacter(actor, target)
+	m.roleCursor = 0
+	for i, name := range m.roleList {
+		if name == suggested {
+			m.roleCursor = i
+		}
+	}
+	m.state = StateNightShowRole
+}
+
+func (m *GrimoireModel) updateNightShowRole(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if m.roleCursor > 0 {
+			m.roleCursor--
+		}
+	case "down", "j":
+		if m.roleCursor < len(m.roleList)-1 {
+			m.roleCursor++
+		}
+	case "enter":
+		actorName := m.nightQueue[m.nightStep]
+		actor := m.game.FindActor(actorName)
+		target := m.game.GetPlayerByID(m.selectedPID)
+		shown := m.roleList[m.roleCursor]
+		if actorName == "Undertaker" {
+			m.game.ResolveUndertaker(actor, target, shown)
+		} else {
+			m.game.ResolveRavenkeeper(actor, target, shown)
+		}
+		m.state = StateNightWalk
+		m.nextStep()
+	case "esc":
+		m.state = StateNightWalk
+	}
+	return m, nil
+}
+
+func (m *GrimoireModel) updateNightStarPass(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if m.selectCursor > 0 {
+			m.selectCursor--
+		}
+	case "down", "j":
+		if m.selectCursor < len(m.game.Players)-1 {
+			m.selectCursor++
+		}
+	case "enter":
+		recipient := m.game.Players[m.selectCursor]
+		if !recipient.IsAlive || recipient.Role.Type != model.Minion {
+			return m, nil
+		}
+		actorName := m.nightQueue[m.nightStep]
+		imp := m.game.FindActor(actorName)
+
+		m.game.StarPass(imp, recipient)
+
+		m.state = StateNightWalk
+		m.nextStep()
+	case "esc":
+		m.state = StateNightSelect
+	}
+	return m, nil
+}
+
 func (m *GrimoireModel) updateNightInfo1(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "up", "k":
@@ -525,9 +915,7 @@ func (m *GrimoireModel) updateNightInfoReveal(msg tea.KeyMsg) (tea.Model, tea.Cm
 		p2 := m.game.Players[m.infoP2]
 		roleName := m.infoRole
 
-		resultMsg := m.game.ResolveInfoAction(actorName, p1, p2, roleName)
-		m.game.Log = append(m.game.Log, fmt.Sprintf("[Night] %s", resultMsg))
-		m.game.SaveState()
+		m.game.ResolveInfoAction(actorName, p1, p2, roleName)
 
 		m.state = StateNightWalk
 		m.nextStep()
@@ -549,16 +937,8 @@ func (m *GrimoireModel) updateNightFortuneRedHerring(msg tea.KeyMsg) (tea.Model,
 			m.selectCursor++
 		}
 	case "enter":
-		// Set Red Herring
-		// Logic: Clear previous, set new
-		for _, p := range m.game.Players {
-			p.IsRedHerring = false
-		}
-		target := m.game.Players[m.selectCursor]
-		target.IsRedHerring = true
-
-		m.game.Log = append(m.game.Log, fmt.Sprintf("[Setup] Fortune Teller Red Herring set to %s", target.Name))
-		m.game.SaveState()
+		// Set Red Herring (clears the previous one)
+		m.game.SetRedHerring(m.selectCursor)
 
 		m.state = StateNightWalk
 	case "esc":
@@ -577,22 +957,12 @@ func (m *GrimoireModel) updateNightFortuneReveal(msg tea.KeyMsg) (tea.Model, tea
 
 		// Find actor logic re-implemented or helper needed?
 		// We need the actor *Player* object for ResolveFortuneTeller to check poison/drunk
-		var actor *model.Player
-		for _, p := range m.game.Players {
-			if p.Role.Name == actorName {
-				actor = p
-				break
-			}
-		}
+		actor := m.game.FindActor(actorName)
 
-		resultMsg := "Error: Actor not found"
 		if actor != nil {
-			resultMsg = m.game.ResolveFortuneTeller(actor, p1, p2)
+			m.game.ResolveFortuneTeller(actor, p1, p2)
 		}
 
-		m.game.Log = append(m.game.Log, fmt.Sprintf("[Night] %s", resultMsg))
-		m.game.SaveState()
-
 		m.state = StateNightWalk
 		m.nextStep()
 	case "esc":
@@ -626,6 +996,26 @@ func (m *GrimoireModel) View() string {
 		return m.viewEditRoleSelect()
 	case StateRoleInfo:
 		return m.viewRoleInfo()
+	case StateNominateNominator:
+		return m.viewNominateNominator()
+	case StateNominateNominee:
+		return m.viewNominateNominee()
+	case StateVoting:
+		return m.viewVoting()
+	case StateGameOver:
+		return m.viewGameOver()
+	case StateNightStarPass:
+		return m.viewNightStarPass()
+	case StateBluffSelect:
+		return m.viewBluffSelect()
+	case StateLog:
+		return m.viewLog()
+	case StateReplay:
+		return m.viewReplay()
+	case StateReminders:
+		return m.viewReminders()
+	case StateNightShowRole:
+		return m.viewNightShowRole()
 	}
 	return m.viewOverview()
 }
@@ -649,11 +1039,135 @@ func (m *GrimoireModel) viewRoleInfo() string {
 	if len(r.Reminders) > 0 {
 		s.WriteString(fmt.Sprintf("\nReminders: %v\n", r.Reminders))
 	}
+	if len(p.Reminders) > 0 {
+		s.WriteString(fmt.Sprintf("\nTokens:    %s\n", formatReminders(p)))
+	}
+	for i, e := range p.Effects {
+		label := "\nEffects:   "
+		if i > 0 {
+			label = "           "
+		}
+		s.WriteString(label + m.formatEffect(e) + "\n")
+	}
+	if len(p.RoleHistory) > 0 {
+		s.WriteString(fmt.Sprintf("\nHistory:   %s\n", formatRoleHistory(p)))
+	}
 
 	s.WriteString("\n\n(Esc) Back")
 	return s.String()
 }
 
+func (m *GrimoireModel) viewNominateNominator() string {
+	s := strings.Builder{}
+	s.WriteString(StyleGridHeader.Render(" WHO IS NOMINATING? ") + "\n\n")
+	s.WriteString(m.renderGrimoireTable(m.selectCursor, nil))
+	s.WriteString("\n(Enter) Confirm Nominator • (Esc) Cancel")
+	return s.String()
+}
+
+func (m *GrimoireModel) viewNominateNominee() string {
+	s := strings.Builder{}
+	nominator := m.game.Players[m.nominatorIdx]
+	s.WriteString(StyleGridHeader.Render(" "+strings.ToUpper(nominator.Name)+" NOMINATES... ") + "\n\n")
+	marks := map[int]string{m.nominatorIdx: "[Nominator]"}
+	s.WriteString(m.renderGrimoireTable(m.selectCursor, marks))
+	s.WriteString("\n(Enter) Confirm Nominee • (Esc) Back")
+	return s.String()
+}
+
+func (m *GrimoireModel) viewVoting() string {
+	nom := m.game.CurrentNomination()
+	if nom == nil {
+		return "No vote in progress."
+	}
+	nominator := m.game.GetPlayerByID(nom.NominatorID)
+	nominee := m.game.GetPlayerByID(nom.NomineeID)
+
+	s := strings.Builder{}
+	s.WriteString(StyleGridHeader.Render(fmt.Sprintf(" VOTE: %s nominated %s ", nominator.Name, nominee.Name)) + "\n\n")
+
+	// Mark each hand so far
+	marks := make(map[int]string)
+	for _, v := range nom.Votes {
+		idx := m.game.IndexOfPlayer(v.PlayerID)
+		if v.Raised {
+			marks[idx] = "✋"
+		} else {
+			marks[idx] = "—"
+		}
+	}
+	for _, p := range m.game.Players {
+		if !m.game.CanVote(p) {
+			if _, voted := marks[m.game.IndexOfPlayer(p.ID)]; !voted {
+				marks[m.game.IndexOfPlayer(p.ID)] = "(no vote)"
+			}
+		}
+	}
+
+	cursor := -1
+	voter := m.game.NextVoter()
+	if voter != nil {
+		cursor = m.game.IndexOfPlayer(voter.ID)
+	}
+	s.WriteString(m.renderGrimoireTable(cursor, marks))
+
+	s.WriteString(fmt.Sprintf("\nVotes: %d   |   Needed: %d\n", nom.VoteCount(), nom.Threshold))
+	if voter != nil {
+		ghost := ""
+		if !voter.IsAlive {
+			ghost = " (uses ghost vote)"
+		}
+		s.WriteString(fmt.Sprintf("\nDoes %s raise their hand?%s\n", voter.Name, ghost))
+	}
+	s.WriteString("\n(y/Enter) Hand Raised • (n) No Vote • (u) Undo Vote • (Esc) Cancel Nomination")
+	return s.String()
+}
+
+func (m *GrimoireModel) viewGameOver() string {
+	s := strings.Builder{}
+
+	bannerColor := ColorTownsfolk
+	if m.game.Winner == model.TeamEvil {
+		bannerColor = ColorDemonRed
+	}
+	banner := lipgloss.NewStyle().
+		Foreground(bannerColor).
+		BorderForeground(bannerColor).
+		Border(lipgloss.DoubleBorder()).
+		Bold(true).
+		Padding(1, 4).
+		Render(strings.ToUpper(string(m.game.Winner)) + " WINS")
+
+	s.WriteString(StyleGridHeader.Render(" GAME OVER ") + "\n\n")
+	s.WriteString(banner + "\n\n")
+	s.WriteString(m.game.WinReason + "\n\n")
+
+	// Full reveal
+	s.WriteString(fmt.Sprintf("%-3s | %-12s | %-15s | %-10s | %-5s | %-6s\n", "#", "Name", "Role", "Type", "Team", "Status"))
+	s.WriteString(strings.Repeat("-", 65) + "\n")
+	for _, p := range m.game.Players {
+		status := "ALIVE"
+		if !p.IsAlive {
+			status = "DEAD"
+		}
+		team := model.TeamOf(p)
+		teamStyle := lipgloss.NewStyle().Foreground(ColorTownsfolk)
+		if team == model.TeamEvil {
+			teamStyle = teamStyle.Foreground(ColorDemonRed)
+		}
+
+		rName := fmt.Sprintf("%-15s", roleLabel(p))
+		rType := fmt.Sprintf("%-10s", p.Role.Type)
+		row := fmt.Sprintf("%-3d | %-12s | %s | %s | %s | %-6s %s",
+			p.ID, p.Name, styleRole(rName, p.Role.Type), styleRole(rType, p.Role.Type),
+			teamStyle.Render(fmt.Sprintf("%-5s", team)), status, formatRoleHistory(p))
+		s.WriteString(StyleCell.Render(row) + "\n")
+	}
+
+	s.WriteString("\n(Esc) Review Grimoire • (r) Replay • (u) Undo • (q) Quit • (Ctrl+n) Archive Game & Start New")
+	return s.String()
+}
+
 func (m *GrimoireModel) viewEdit() string {
 	s := strings.Builder{}
 	s.WriteString(StyleGridHeader.Render(" EDIT MODE ") + "\n\n")
@@ -672,7 +1186,7 @@ func (m *GrimoireModel) viewEdit() string {
 		}
 
 		if m.cursor == i {
-			rName := fmt.Sprintf("%-15s", p.Role.Name)
+			rName := fmt.Sprintf("%-15s", roleLabel(p))
 			rType := fmt.Sprintf("%-10s", p.Role.Type)
 
 			coloredRole := styleRole(rName, p.Role.Type)
@@ -682,7 +1196,7 @@ func (m *GrimoireModel) viewEdit() string {
 				cursor, p.ID, p.Name, coloredRole, coloredType)
 			s.WriteString(StyleSelected.Render(row) + "\n")
 		} else {
-			rName := fmt.Sprintf("%-15s", p.Role.Name)
+			rName := fmt.Sprintf("%-15s", roleLabel(p))
 			rType := fmt.Sprintf("%-10s", p.Role.Type)
 
 			coloredRole := styleRole(rName, p.Role.Type)
@@ -782,13 +1296,7 @@ func (m *GrimoireModel) viewNightFortuneReveal() string {
 
 	// Calculate result purely for display (logic repeated in update, harmless)
 	// We need actor player object
-	var actorPlayer *model.Player
-	for _, p := range m.game.Players {
-		if p.Role.Name == actor {
-			actorPlayer = p
-			break
-		}
-	}
+	actorPlayer := m.game.FindActor(actor)
 
 	result := "ERROR"
 	details := ""
@@ -801,7 +1309,7 @@ func (m *GrimoireModel) viewNightFortuneReveal() string {
 		}
 
 		// Malfunction check for UI warning
-		if actorPlayer.IsPoisoned || actorPlayer.IsDrunk {
+		if actorPlayer.IsMalfunctioning() {
 			details = " (MALFUNCTION - LIED?)"
 		}
 	}
@@ -820,6 +1328,44 @@ func (m *GrimoireModel) viewNightFortuneReveal() string {
 	return s.String()
 }
 
+func (m *GrimoireModel) viewBluffSelect() string {
+	s := strings.Builder{}
+	s.WriteString(StyleGridHeader.Render(fmt.Sprintf(" DEMON BLUFFS (%d/3) ", len(m.bluffPicks))) + "\n\n")
+
+	for i, name := range m.roleList {
+		cursor := " "
+		if m.roleCursor == i {
+			cursor = ">"
+		}
+		check := "[ ]"
+		if m.bluffPicks[name] {
+			check = "[x]"
+		}
+
+		line := fmt.Sprintf("%s %s %s", cursor, check, styleRole(name, m.scriptRoleType(name)))
+		if m.roleCursor == i {
+			s.WriteString(StyleSelected.Render(line) + "\n")
+		} else {
+			s.WriteString(StyleCell.Render(line) + "\n")
+		}
+	}
+	s.WriteString("\n(Space) Toggle • (r) Re-roll • (Enter) Confirm • (Esc) Cancel")
+	return s.String()
+}
+
+func (m *GrimoireModel) viewNightStarPass() string {
+	s := strings.Builder{}
+	s.WriteString(StyleGridHeader.Render(" STAR PASS: WHO BECOMES THE DEMON? ") + "\n\n")
+
+	marks := make(map[int]string)
+	for _, p := range m.game.StarPassCandidates() {
+		marks[m.game.IndexOfPlayer(p.ID)] = "[Minion]"
+	}
+	s.WriteString(m.renderGrimoireTable(m.selectCursor, marks))
+	s.WriteString("\n(Enter) Confirm New Demon • (Esc) Back")
+	return s.String()
+}
+
 func (m *GrimoireModel) viewNightInfoSelect1() string {
 	s := strings.Builder{}
 	actor := m.nightQueue[m.nightStep]
@@ -842,6 +1388,42 @@ func (m *GrimoireModel) viewNightInfoSelect2() string {
 	return s.String()
 }
 
+func (m *GrimoireModel) viewNightShowRole() string {
+	s := strings.Builder{}
+	actorName := m.nightQueue[m.nightStep]
+	actor := m.game.FindActor(actorName)
+	target := m.game.GetPlayerByID(m.selectedPID)
+	s.WriteString(StyleGridHeader.Render(" CHARACTER TO SHOW the "+strings.ToUpper(actorName)) + "\n\n")
+
+	s.WriteString(fmt.Sprintf("%s is the %s\n", target.Name, styleRole(target.Role.Name, target.Role.Type)))
+	if target.RegistrationOverride != "" {
+		s.WriteString(fmt.Sprintf("Registers as a %s: %s\n", target.RegistrationOverride, m.game.RegisteredCharacter(target)))
+	}
+	if actor != nil && actor.IsMalfunctioning() {
+		s.WriteString(lipgloss.NewStyle().Foreground(ColorError).Render("The "+actorName+" is Drunk/Poisoned: show a false character") + "\n")
+	}
+	s.WriteString("\n")
+
+	for i, r := range m.roleList {
+		cursor := " "
+		if m.roleCursor == i {
+			cursor = ">"
+		}
+		var rType model.RoleType
+		if def, ok := m.game.Script.FindRole(r); ok {
+			rType = def.Type
+		}
+		line := fmt.Sprintf("%s %s", cursor, styleRole(r, rType))
+		if m.roleCursor == i {
+			s.WriteString(StyleSelected.Render(line) + "\n")
+		} else {
+			s.WriteString(StyleCell.Render(line) + "\n")
+		}
+	}
+	s.WriteString("\n(Enter) Show & Log • (Esc) Cancel")
+	return s.String()
+}
+
 func (m *GrimoireModel) viewNightInfoRole() string {
 	s := strings.Builder{}
 	actor := m.nightQueue[m.nightStep]
@@ -881,13 +1463,7 @@ func (m *GrimoireModel) viewNightWalk() string {
 	roleName := m.nightQueue[m.nightStep]
 
 	// Find player with this role
-	var player *model.Player
-	for _, p := range m.game.Players {
-		if p != nil && p.Role.Name == roleName {
-			player = p
-			break
-		}
-	}
+	player := m.game.FindActor(roleName)
 
 	s := strings.Builder{}
 	s.WriteString(StyleGridHeader.Render(" NIGHT PHASE ") + "\n\n")
@@ -898,6 +1474,68 @@ func (m *GrimoireModel) viewNightWalk() string {
 
 	s.WriteString(fmt.Sprintf("Step %d/%d:  %s\n\n", m.nightStep+1, len(m.nightQueue), strings.ToUpper(roleName)))
 
+	switch roleName {
+	case model.MinionInfoStep, model.DemonInfoStep:
+		s.WriteString(m.viewEvilInfo(roleName))
+	default:
+		s.WriteString(m.viewNightActor(player))
+	}
+
+	s.WriteString("\n\n(Enter) Next • (Esc) Skip Night")
+	return s.String()
+}
+
+// viewEvilInfo describes the first-night Minion and Demon info steps.
+func (m *GrimoireModel) viewEvilInfo(step string) string {
+	s := strings.Builder{}
+
+	demonName := "(none)"
+	if demon := m.game.Demon(); demon != nil {
+		demonName = fmt.Sprintf("%s (%s)", demon.Name, styleRole(demon.Role.Name, demon.Role.Type))
+	}
+	var minions []string
+	for _, p := range m.game.Minions() {
+		minions = append(minions, fmt.Sprintf("%s (%s)", p.Name, styleRole(p.Role.Name, p.Role.Type)))
+	}
+
+	if step == model.MinionInfoStep {
+		s.WriteString(fmt.Sprintf("Wake the Minions: %s\n", strings.Join(minions, ", ")))
+		s.WriteString(fmt.Sprintf("Show them the Demon: %s\n", demonName))
+		s.WriteString("\nPress Enter once they have seen each other.")
+		return s.String()
+	}
+
+	s.WriteString(fmt.Sprintf("Wake the Demon: %s\n", demonName))
+	s.WriteString(fmt.Sprintf("Show them their Minions: %s\n", strings.Join(minions, ", ")))
+	s.WriteString(fmt.Sprintf("Show them 3 bluffs: %s\n", m.formatBluffs()))
+	s.WriteString("\n(b) Change bluffs • Press Enter once shown.")
+	return s.String()
+}
+
+// scriptRoleType looks up a role's type in the script for coloring.
+func (m *GrimoireModel) scriptRoleType(name string) model.RoleType {
+	for _, def := range m.game.Script.Roles {
+		if def.Name == name {
+			return def.Type
+		}
+	}
+	return ""
+}
+
+func (m *GrimoireModel) formatBluffs() string {
+	if len(m.game.DemonBluffs) == 0 {
+		return "(none set)"
+	}
+	var parts []string
+	for _, b := range m.game.DemonBluffs {
+		parts = append(parts, styleRole(b, m.scriptRoleType(b)))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// viewNightActor shows the player who wakes for the current step.
+func (m *GrimoireModel) viewNightActor(player *model.Player) string {
+	s := strings.Builder{}
 	if player != nil {
 		status := "Alive"
 		if !player.IsAlive {
@@ -906,13 +1544,13 @@ func (m *GrimoireModel) viewNightWalk() string {
 
 		// Status Effects
 		effects := ""
-		if player.IsPoisoned {
+		if player.IsPoisoned() {
 			effects += " ☠️ POISONED"
 		}
-		if player.IsDrunk {
+		if player.IsDrunk() {
 			effects += " 🍺 DRUNK"
 		}
-		if player.IsProtected {
+		if player.IsProtected() {
 			effects += " 🛡️ PROTECTED"
 		}
 
@@ -922,33 +1560,62 @@ func (m *GrimoireModel) viewNightWalk() string {
 			team = "EVIL"
 		}
 
+		acting := player.ActingRole()
 		s.WriteString(fmt.Sprintf("Player: %s\n", player.Name))
+		if player.ShownRole.Name != "" {
+			s.WriteString(fmt.Sprintf("Really: %s (believes they are the %s)\n", player.Role.Name, acting.Name))
+		}
 		s.WriteString(fmt.Sprintf("Status: %s%s\n", status, effects))
 		s.WriteString(fmt.Sprintf("Team:   %s (%s)\n", team, player.Role.Type))
-		s.WriteString(fmt.Sprintf("Ability: %s\n\n", player.Role.Ability))
-		if len(player.Role.Reminders) > 0 {
-			s.WriteString(fmt.Sprintf("Reminders: %v\n", player.Role.Reminders))
+		s.WriteString(fmt.Sprintf("Ability: %s\n\n", acting.Ability))
+		if len(acting.Reminders) > 0 {
+			s.WriteString(fmt.Sprintf("Reminders: %v\n", acting.Reminders))
 		}
 
 		// Empath Logic
-		if player.Role.Name == "Empath" {
+		if acting.Name == "Empath" {
 			info := m.game.GetEmpathInfo(player)
 			s.WriteString(fmt.Sprintf("\n[Empath Info]\n%s\n", info))
 		}
 
+		if acting.Name == "Chef" {
+			s.WriteString(fmt.Sprintf("\n[Chef Info]\nPairs of evil neighbors: %d\n", m.game.ChefCount()))
+			if player.IsMalfunctioning() {
+				s.WriteString(fmt.Sprintf("Suggested false number: %d (Is Drunk/Poisoned)\n", model.ChefFalseCount(m.game.ChefCount())))
+			}
+		}
+
+		if acting.Name == "Undertaker" {
+			s.WriteString("\n[Undertaker Info]\n")
+			if executed := m.game.ExecutedToday(); executed != nil {
+				s.WriteString(fmt.Sprintf("Executed today: %s (%s)\n", executed.Name, executed.Role.Name))
+			} else {
+				s.WriteString("Nobody was executed today.\n")
+			}
+		}
+
 		s.WriteString("\n[Action Required]\n")
 
-		if player.Role.ActionType == model.ActionSelectPlayer {
+		switch {
+		case acting.Name == "Chef":
+			s.WriteString(fmt.Sprintf("Show the Chef %d. (+/-) Change • Press Enter to log it and continue.", m.chefNumber(player)))
+		case acting.Name == "Undertaker" && !player.IsAlive:
+			s.WriteString("The Undertaker is dead and does not wake. Press Enter to continue.")
+		case acting.Name == "Undertaker" && m.game.ExecutedToday() == nil:
+			s.WriteString("The Undertaker does not wake. Press Enter to continue.")
+		case acting.Name == "Undertaker":
+			s.WriteString("(Press Enter to choose the character to show)")
+		case acting.Name == "Ravenkeeper" && !m.game.DiedTonight(player):
+			s.WriteString("The Ravenkeeper did not die tonight and does not wake. Press Enter to continue.")
+		case acting.ActionType == model.ActionSelectPlayer:
 			s.WriteString("(Press Enter to select a target player)")
-		} else {
+		default:
 			s.WriteString("Perform action physically. Press Enter to continue.")
 		}
 
 	} else {
 		s.WriteString("(Role not in play. Skip?)")
 	}
-
-	s.WriteString("\n\n(Enter) Next • (Esc) Skip Night")
 	return s.String()
 }
 
@@ -978,18 +1645,47 @@ func (m *GrimoireModel) viewOverview() string {
 
 	s.WriteString(StyleGridHeader.Render(header) + "\n\n")
 
+	if m.game.Winner != "" {
+		banner := fmt.Sprintf("GAME OVER: %s wins. %s", m.game.Winner, m.game.WinReason)
+		s.WriteString(lipgloss.NewStyle().Foreground(ColorGold).Bold(true).Render(banner) + "\n\n")
+	}
+
+	if m.game.Phase == model.PhaseDay {
+		if block, votes := m.game.OnTheBlock(); block != nil {
+			s.WriteString(fmt.Sprintf("On the block: %s (%d votes)\n\n", block.Name, votes))
+		} else {
+			s.WriteString(fmt.Sprintf("Nobody on the block (%d votes needed)\n\n", m.game.VoteThreshold()))
+		}
+	}
+
+	s.WriteString(fmt.Sprintf("Demon bluffs: %s\n\n", m.formatBluffs()))
+
 	s.WriteString(m.renderGrimoireTable(m.cursor, nil))
-	s.WriteString("\n\n(j/k) Move • (e) Edit • (i) Info • (enter) Toggle Life • (g) Ghost Vote • (R) Reg • (n) Next Phase • (u) Undo")
+	if m.statusMsg != "" {
+		s.WriteString("\n" + lipgloss.NewStyle().Foreground(ColorError).Render(m.statusMsg))
+	}
+	history := fmt.Sprintf("Undo: %d • Redo: %d", m.game.History.Len(), m.game.Future.Len())
+	s.WriteString("\n" + lipgloss.NewStyle().Foreground(ColorSubtext).Render(history))
+	s.WriteString("\n\n(j/k) Move • (e) Edit • (i) Info • (enter) Toggle Life • (g) Ghost Vote • (R) Reg • (b) Bluffs • (v) Nominate • (n) Next Phase • (t) Tokens • (L) Log • (T) Replay • (tab) Circle/Table • (u) Undo • (ctrl+r) Redo")
 	return s.String()
 }
 
 func (m *GrimoireModel) renderGrimoireTable(activeCursor int, marks map[int]string) string {
+	if m.townSquare {
+		return m.renderTownSquare(activeCursor, marks)
+	}
+	return renderPlayerTable(m.game.Players, activeCursor, marks)
+}
+
+// renderPlayerTable draws the grimoire for any set of players, such as a past
+// state in the replay.
+func renderPlayerTable(players []*model.Player, activeCursor int, marks map[int]string) string {
 	s := strings.Builder{}
 	// Table header
 	s.WriteString(fmt.Sprintf("%-3s | %-12s | %-15s | %-10s | %-8s | %-10s\n", "#", "Name", "Role", "Type", "Status", "Effects"))
 	s.WriteString(strings.Repeat("-", 80) + "\n")
 
-	for i, p := range m.game.Players {
+	for i, p := range players {
 		if p == nil {
 			continue
 		}
@@ -1019,19 +1715,23 @@ func (m *GrimoireModel) renderGrimoireTable(activeCursor int, marks map[int]stri
 
 		// Status Effects
 		effects := ""
-		if p.IsPoisoned {
+		if p.IsPoisoned() {
 			effects += "☠️ "
 		}
-		if p.IsDrunk {
+		if p.IsDrunk() {
 			effects += "🍺 "
 		}
-		if p.IsProtected {
+		if p.IsProtected() {
 			effects += "🛡️ "
 		}
 		// Red Herring
 		if p.IsRedHerring {
 			effects += "🚩 "
 		}
+		// Inherited Demon (Star Pass / Scarlet Woman)
+		if p.BecameDemon() {
+			effects += "★ "
+		}
 
 		// Custom Marks (e.g. selection numbers)
 		if marks != nil {
@@ -1044,7 +1744,7 @@ func (m *GrimoireModel) renderGrimoireTable(activeCursor int, marks map[int]stri
 		isSelected := activeCursor == i
 
 		// Role Coloring
-		rName := fmt.Sprintf("%-15s", p.Role.Name)
+		rName := fmt.Sprintf("%-15s", roleLabel(p))
 		rType := fmt.Sprintf("%-10s", p.Role.Type)
 
 		coloredRole := styleRole(rName, p.Role.Type)
@@ -1052,6 +1752,9 @@ func (m *GrimoireModel) renderGrimoireTable(activeCursor int, marks map[int]stri
 
 		row := fmt.Sprintf("%s %-3d | %-12s | %s | %s | %-8s | %-10s%s",
 			cursor, p.ID, p.Name, coloredRole, coloredType, status, effects, override)
+		if len(p.Reminders) > 0 {
+			row += "  " + styleReminder.Render(formatReminders(p))
+		}
 
 		if isSelected {
 			s.WriteString(StyleSelected.Render(row) + "\n")
@@ -1063,6 +1766,10 @@ func (m *GrimoireModel) renderGrimoireTable(activeCursor int, marks map[int]stri
 }
 
 func styleRole(name string, roleType model.RoleType) string {
+	return roleStyle(roleType).Render(name)
+}
+
+func roleStyle(roleType model.RoleType) lipgloss.Style {
 	style := lipgloss.NewStyle()
 	switch roleType {
 	case model.Townsfolk:
@@ -1074,7 +1781,41 @@ func styleRole(name string, roleType model.RoleType) string {
 	case model.Demon:
 		style = style.Foreground(ColorDemonRed)
 	}
-	return style.Render(name)
+	return style
+}
+
+// roleLabel shows the true character, plus the one the Drunk believes they are.
+func roleLabel(p *model.Player) string {
+	if p.ShownRole.Name != "" {
+		return fmt.Sprintf("%s (%s)", p.Role.Name, p.ShownRole.Name)
+	}
+	return p.Role.Name
+}
+
+// formatEffect describes an effect and who caused it.
+func (m *GrimoireModel) formatEffect(e model.Effect) string {
+	if source := m.game.GetPlayerByID(e.SourceID); source != nil {
+		return fmt.Sprintf("%s (%s)", e, source.Name)
+	}
+	return e.String()
+}
+
+// formatReminders lists the reminder tokens on a player.
+func formatReminders(p *model.Player) string {
+	tokens := make([]string, len(p.Reminders))
+	for i, r := range p.Reminders {
+		tokens[i] = r.Token
+	}
+	return strings.Join(tokens, ", ")
+}
+
+// formatRoleHistory lists the characters a player held before their current one.
+func formatRoleHistory(p *model.Player) string {
+	var parts []string
+	for _, h := range p.RoleHistory {
+		parts = append(parts, fmt.Sprintf("was %s (%s, turn %d)", h.Role, h.Reason, h.Turn))
+	}
+	return strings.Join(parts, ", ")
 }
 
 func styleRoleType(t model.RoleType) string {
@@ -0,0 +1,198 @@
+package tui
+
+import (
+	"clocktower/model"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Log panel: the game's events in a scrollable viewport, filtered by phase,
+// turn and player. Results given while the actor was poisoned or drunk are
+// highlighted.
+
+// Lines taken by the log panel's header and help text
+const logChrome = 7
+
+// logFilter narrows the log. Zero values show everything, except turn, which
+// uses allTurns since turn 0 is the setup.
+type logFilter struct {
+	phase    model.Phase
+	turn     int
+	playerID int
+}
+
+const allTurns = -1
+
+func (f logFilter) match(e model.Event) bool {
+	if f.phase != "" && e.Phase != f.phase {
+		return false
+	}
+	if f.turn != allTurns && e.Turn != f.turn {
+		return false
+	}
+	if f.playerID != 0 && !eventInvolves(e, f.playerID) {
+		return false
+	}
+	return true
+}
+
+func eventInvolves(e model.Event, id int) bool {
+	if e.Actor == id {
+		return true
+	}
+	for _, t := range e.Targets {
+		if t == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *GrimoireModel) openLog() {
+	m.logFilter = logFilter{turn: allTurns}
+	m.logView = viewport.New(m.logSize())
+	m.refreshLog()
+	m.logView.GotoBottom()
+	m.state = StateLog
+}
+
+// logSize fits the viewport to the terminal, with a fallback until the first
+// WindowSizeMsg arrives.
+func (m *GrimoireModel) logSize() (width, height int) {
+	width, height = 100, 20
+	if m.width > 0 {
+		width = m.width
+	}
+	if m.height > logChrome+3 {
+		height = m.height - logChrome
+	}
+	return width, height
+}
+
+func (m *GrimoireModel) refreshLog() {
+	var lines []string
+	for _, e := range m.game.Events {
+		if !m.logFilter.match(e) {
+			continue
+		}
+		lines = append(lines, formatLogLine(e))
+	}
+	if len(lines) == 0 {
+		lines = []string{lipgloss.NewStyle().Foreground(ColorSubtext).Render("No matching log entries.")}
+	}
+	m.logView.SetContent(strings.Join(lines, "\n"))
+}
+
+// formatLogLine renders an event with its phase and turn, highlighting
+// malfunctions.
+func formatLogLine(e model.Event) string {
+	when := fmt.Sprintf("%s %d", e.Phase, e.Turn)
+	line := fmt.Sprintf("%-9s %s", when, e.String())
+	if e.Malfunction {
+		return lipgloss.NewStyle().Foreground(ColorError).Render("⚠ " + line + " (malfunction)")
+	}
+	return line
+}
+
+func (m *GrimoireModel) updateLog(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q", "L":
+		m.state = StateOverview
+		return m, nil
+	case "n":
+		// All -> Night -> Day -> All
+		switch m.logFilter.phase {
+		case "":
+			m.logFilter.phase = model.PhaseNight
+		case model.PhaseNight:
+			m.logFilter.phase = model.PhaseDay
+		default:
+			m.logFilter.phase = ""
+		}
+	case "t":
+		m.logFilter.turn = m.nextLogTurn()
+	case "p":
+		m.logFilter.playerID = m.nextLogPlayer()
+	case "c":
+		m.logFilter = logFilter{turn: allTurns}
+	default:
+		var cmd tea.Cmd
+		m.logView, cmd = m.logView.Update(msg)
+		return m, cmd
+	}
+	m.refreshLog()
+	m.logView.GotoTop()
+	return m, nil
+}
+
+// nextLogTurn cycles through the turns that have log entries, then back to
+// all turns.
+func (m *GrimoireModel) nextLogTurn() int {
+	var turns []int
+	seen := make(map[int]bool)
+	for _, e := range m.game.Events {
+		if !seen[e.Turn] {
+			seen[e.Turn] = true
+			turns = append(turns, e.Turn)
+		}
+	}
+	for i, t := range turns {
+		if t == m.logFilter.turn {
+			if i+1 < len(turns) {
+				return turns[i+1]
+			}
+			return allTurns
+		}
+	}
+	if len(turns) > 0 {
+		return turns[0]
+	}
+	return allTurns
+}
+
+// nextLogPlayer cycles through the players in seat order, then back to all
+// players.
+func (m *GrimoireModel) nextLogPlayer() int {
+	players := m.game.Players
+	for i, p := range players {
+		if p.ID == m.logFilter.playerID {
+			if i+1 < len(players) {
+				return players[i+1].ID
+			}
+			return 0
+		}
+	}
+	if len(players) > 0 {
+		return players[0].ID
+	}
+	return 0
+}
+
+func (m *GrimoireModel) viewLog() string {
+	s := strings.Builder{}
+	s.WriteString(StyleGridHeader.Render(" GAME LOG ") + "\n\n")
+
+	phase := "All"
+	if m.logFilter.phase != "" {
+		phase = string(m.logFilter.phase)
+	}
+	turn := "All"
+	if m.logFilter.turn != allTurns {
+		turn = fmt.Sprint(m.logFilter.turn)
+	}
+	player := "All"
+	if p := m.game.GetPlayerByID(m.logFilter.playerID); p != nil {
+		player = p.Name
+	}
+	filters := fmt.Sprintf("Phase: %s • Turn: %s • Player: %s", phase, turn, player)
+	s.WriteString(lipgloss.NewStyle().Foreground(ColorSubtext).Render(filters) + "\n\n")
+
+	s.WriteString(m.logView.View())
+	s.WriteString(fmt.Sprintf("\n\n(j/k) Scroll • (n) Night/Day • (t) Turn • (p) Player • (c) Clear Filters • (Esc) Back   %3.f%%",
+		m.logView.ScrollPercent()*100))
+	return s.String()
+}
@@ -0,0 +1,95 @@
+package tui
+
+import (
+	"clocktower/model"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Reminder tokens: the tokens on the selected player, which can be removed,
+// followed by every token of the characters in play, which can be added.
+
+type reminderEntry struct {
+	reminder model.Reminder
+	placed   bool
+}
+
+func (m *GrimoireModel) reminderEntries() []reminderEntry {
+	var entries []reminderEntry
+	for _, r := range m.game.Players[m.cursor].Reminders {
+		entries = append(entries, reminderEntry{reminder: r, placed: true})
+	}
+	for _, r := range m.game.ReminderOptions() {
+		entries = append(entries, reminderEntry{reminder: r})
+	}
+	return entries
+}
+
+func (m *GrimoireModel) updateReminders(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	entries := m.reminderEntries()
+	switch msg.String() {
+	case "esc", "q", "t":
+		m.state = StateOverview
+	case "up", "k":
+		if m.reminderCursor > 0 {
+			m.reminderCursor--
+		}
+	case "down", "j":
+		if m.reminderCursor < len(entries)-1 {
+			m.reminderCursor++
+		}
+	case "enter":
+		if m.reminderCursor >= len(entries) {
+			return m, nil
+		}
+		entry := entries[m.reminderCursor]
+		var err error
+		if entry.placed {
+			err = m.game.RemoveReminder(m.cursor, entry.reminder)
+		} else {
+			err = m.game.AddReminder(m.cursor, entry.reminder)
+		}
+		if err != nil {
+			m.statusMsg = err.Error()
+		}
+		// Keep the cursor on the same option as the list above it changes
+		if n := len(m.reminderEntries()); m.reminderCursor >= n {
+			m.reminderCursor = n - 1
+		}
+	}
+	return m, nil
+}
+
+func (m *GrimoireModel) viewReminders() string {
+	s := strings.Builder{}
+	p := m.game.Players[m.cursor]
+	s.WriteString(StyleGridHeader.Render(fmt.Sprintf(" REMINDER TOKENS: %s ", p.Name)) + "\n\n")
+
+	entries := m.reminderEntries()
+	if len(p.Reminders) == 0 {
+		s.WriteString(lipgloss.NewStyle().Foreground(ColorSubtext).Render("No tokens on this player.") + "\n")
+	}
+	for i, entry := range entries {
+		if i == len(p.Reminders) {
+			s.WriteString("\nAdd a token:\n")
+		}
+		label := entry.reminder.String()
+		if entry.placed {
+			label = styleReminder.Render(label)
+		}
+		if i == m.reminderCursor {
+			s.WriteString(StyleSelected.Render("> "+label) + "\n")
+		} else {
+			s.WriteString(StyleCell.Render("  "+label) + "\n")
+		}
+	}
+	if len(entries) == len(p.Reminders) {
+		s.WriteString("\n" + lipgloss.NewStyle().Foreground(ColorSubtext).Render("No characters in play have reminder tokens.") + "\n")
+	}
+
+	s.WriteString("\n(Enter) Remove / Add Token • (Esc) Back")
+	return s.String()
+}
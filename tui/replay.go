@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Replay: steps through the game phase by phase, showing the grimoire as it
+// was at the end of each one next to that phase's log entries. Past states are
+// rebuilt from the event log, so the live game is never touched.
+
+// Lines taken by the replay's header, table border and help text
+const replayChrome = 10
+
+func (m *GrimoireModel) openReplay() {
+	if !m.game.CanReplay() {
+		m.statusMsg = "This game was saved by an older version and cannot be replayed"
+		m.state = StateOverview
+		return
+	}
+	m.replayChapters = m.game.Timeline()
+	m.replayView = viewport.New(m.replaySize())
+	m.state = StateReplay
+	m.showChapter(0)
+}
+
+func (m *GrimoireModel) replaySize() (width, height int) {
+	width, height = 100, 10
+	if m.width > 0 {
+		width = m.width
+	}
+	if rest := m.height - replayChrome - len(m.game.Players); rest > 3 {
+		height = rest
+	}
+	return width, height
+}
+
+// showChapter rebuilds the state at the end of a chapter and lists its log.
+func (m *GrimoireModel) showChapter(idx int) {
+	m.replayIndex = idx
+	chapter := m.replayChapters[idx]
+
+	past, err := m.game.StateAt(chapter.End)
+	m.replayGame = past
+	m.replayErr = err
+
+	var lines []string
+	for _, e := range m.game.Events[chapter.Start:chapter.End] {
+		lines = append(lines, formatLogLine(e))
+	}
+	if len(lines) == 0 {
+		lines = []string{lipgloss.NewStyle().Foreground(ColorSubtext).Render("Nothing happened.")}
+	}
+	m.replayView.SetContent(strings.Join(lines, "\n"))
+	m.replayView.GotoTop()
+}
+
+func (m *GrimoireModel) updateReplay(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	last := len(m.replayChapters) - 1
+	switch msg.String() {
+	case "esc", "q", "T":
+		m.replayGame = nil
+		m.state = StateOverview
+	case "right", "l", "n":
+		if m.replayIndex < last {
+			m.showChapter(m.replayIndex + 1)
+		}
+	case "left", "h", "p":
+		if m.replayIndex > 0 {
+			m.showChapter(m.replayIndex - 1)
+		}
+	case "home":
+		m.showChapter(0)
+	case "end":
+		m.showChapter(last)
+	default:
+		var cmd tea.Cmd
+		m.replayView, cmd = m.replayView.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m *GrimoireModel) viewReplay() string {
+	s := strings.Builder{}
+	chapter := m.replayChapters[m.replayIndex]
+	title := fmt.Sprintf(" REPLAY: %s (%d/%d) ", chapter, m.replayIndex+1, len(m.replayChapters))
+	s.WriteString(StyleGridHeader.Render(title) + "\n\n")
+
+	if m.replayErr != nil {
+		s.WriteString(lipgloss.NewStyle().Foreground(ColorError).Render("Could not rebuild this point: "+m.replayErr.Error()) + "\n\n")
+	} else {
+		s.WriteString(renderPlayerTable(m.replayGame.Players, -1, nil) + "\n")
+	}
+
+	s.WriteString(m.replayView.View())
+	s.WriteString("\n\n(h/l) Previous/Next Phase • (j/k) Scroll Log • (Home/End) First/Last • (Esc) Back")
+	return s.String()
+}
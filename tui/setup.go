@@ -2,28 +2,68 @@ package tui
 
 import (
 	"clocktower/model"
-	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
+	"math/rand"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type SetupStep int
+
+const (
+	StepScript SetupStep = iota
+	StepPlayerCount
+	StepPlayerNames
+	StepRoleMode
+	StepSetupChoice
+	StepBagSelect
+	StepDealMode
+	StepSeatAssign
+	StepConfirmRoles
+	StepDrunk
 )
 
 type SetupModel struct {
 	form     *huh.Form
 	game     *model.Game
+	step     SetupStep
 	width    int
 	height   int
 	finished bool
+	// Character counts after setup modifiers, shown before confirming
+	distribution model.Distribution
+	err          error
+	// Manual bag
+	manualBag bool
+	bag       []string // Role names picked by the storyteller
+	seatRoles []string // Role name per seat when assigning by hand
+	confirmed bool
+	// Scripts offered in the first step
+	scripts  []model.ScriptSource
+	gameName string
+	// The script as loaded, before any setup modifier is reversed
+	script       model.Script
+	setupChoices []setupChoice
+}
+
+// setupChoice is a reversible setup modifier (e.g. the Godfather's -1 or +1
+// Outsider) and the sign the storyteller picked for it.
+type setupChoice struct {
+	role     model.Role
+	reversed bool
 }
 
-func NewSetupModel(game *model.Game) *SetupModel {
+func NewSetupModel(game *model.Game, scripts []model.ScriptSource) *SetupModel {
 	// Initialize with empty form, will be built in Init or Update
 	m := &SetupModel{
-		game: game,
+		game:    game,
+		scripts: scripts,
 	}
 	m.form = m.buildScriptSelectionForm()
 	return m
@@ -46,25 +86,102 @@ func (m *SetupModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Check if current form is completed
 	if m.form.State == huh.StateCompleted {
 		// Logic to move to next step/form or finish setup
-		if m.game.Script.Name == "" {
+		switch m.step {
+		case StepScript:
 			// Script just selected, load it
-			m.loadScript()
+			if err := m.loadScript(); err != nil {
+				// Stay on the script picker and show every problem
+				m.err = err
+				m.form = m.buildScriptSelectionForm()
+				cmds = append(cmds, m.form.Init())
+				break
+			}
+			m.err = nil
+			m.game.Name = strings.TrimSpace(m.gameName)
 			// Now ask for player count
 			m.form = m.buildPlayerCountForm()
+			m.step = StepPlayerCount
 			cmds = append(cmds, m.form.Init())
-		} else if len(m.game.Players) == 0 {
+		case StepPlayerCount:
 			// Player count selected, build names form
 			countStr := m.form.GetString("player_count")
 			count, _ := strconv.Atoi(countStr)
 			m.form = m.buildPlayerNamesForm(count)
+			m.step = StepPlayerNames
 			cmds = append(cmds, m.form.Init())
-		} else if m.game.Players[0] == nil {
+		case StepPlayerNames:
 			// Names entered
 			count := len(m.game.Players)
 			for i := 0; i < count; i++ {
 				name := m.form.GetString(fmt.Sprintf("player_%d", i))
 				m.game.Players[i] = model.NewPlayer(i+1, name)
 			}
+			cmds = append(cmds, m.chooseRoleMode())
+		case StepRoleMode:
+			m.err = nil
+			m.manualBag = m.form.GetString("role_mode") == "manual"
+			if len(m.setupChoices) > 0 {
+				m.form = m.buildSetupChoiceForm()
+				m.step = StepSetupChoice
+				cmds = append(cmds, m.form.Init())
+				break
+			}
+			cmds = append(cmds, m.chooseRoles())
+		case StepSetupChoice:
+			if err := m.applySetupChoices(); err != nil {
+				m.err = err
+				m.form = m.buildSetupChoiceForm()
+				cmds = append(cmds, m.form.Init())
+				break
+			}
+			cmds = append(cmds, m.chooseRoles())
+		case StepBagSelect:
+			m.form = m.buildDealModeForm()
+			m.step = StepDealMode
+			cmds = append(cmds, m.form.Init())
+		case StepDealMode:
+			if m.form.GetString("deal_mode") == "random" {
+				m.dealBag()
+				cmds = append(cmds, m.confirmRoles())
+				break
+			}
+			// Start each seat on a different character
+			m.seatRoles = make([]string, len(m.game.Players))
+			copy(m.seatRoles, m.bag)
+			m.form = m.buildSeatAssignForm()
+			m.step = StepSeatAssign
+			cmds = append(cmds, m.form.Init())
+		case StepSeatAssign:
+			if err := m.checkSeatRoles(); err != nil {
+				m.err = err
+				m.form = m.buildSeatAssignForm()
+				cmds = append(cmds, m.form.Init())
+				break
+			}
+			m.err = nil
+			for i, p := range m.game.Players {
+				p.Role, _ = m.game.Script.FindRole(m.seatRoles[i])
+			}
+			cmds = append(cmds, m.confirmRoles())
+		case StepConfirmRoles:
+			if !m.confirmed {
+				if m.manualBag {
+					cmds = append(cmds, m.chooseRoleMode())
+				} else {
+					// Re-deal
+					cmds = append(cmds, m.dealRoles())
+				}
+				break
+			}
+			cmds = append(cmds, m.afterRolesDealt())
+		case StepDrunk:
+			if err := m.game.SetDrunkIdentity(m.drunkIndex(), m.form.GetString("drunk_role")); err != nil {
+				m.err = err
+				m.form = m.buildDrunkForm()
+				cmds = append(cmds, m.form.Init())
+				break
+			}
+			m.err = nil
 			m.finished = true
 		}
 	}
@@ -76,35 +193,102 @@ func (m *SetupModel) View() string {
 	if m.finished {
 		return "Setup Complete! Press Enter to start."
 	}
+	if m.err != nil {
+		return lipgloss.NewStyle().Foreground(ColorError).Render(m.err.Error()) + "\n\n" + m.form.View()
+	}
 	return m.form.View()
 }
 
 // Helpers
 
 func (m *SetupModel) buildScriptSelectionForm() *huh.Form {
-	// Find scripts
-	files, _ := filepath.Glob("data/scripts/*.json")
-	options := make([]huh.Option[string], len(files))
-	for i, f := range files {
-		options[i] = huh.NewOption(filepath.Base(f), f)
+	options := make([]huh.Option[int], len(m.scripts))
+	for i, source := range m.scripts {
+		options[i] = huh.NewOption(source.Label(), i)
 	}
 
 	return huh.NewForm(
 		huh.NewGroup(
-			huh.NewSelect[string]().
-				Key("script_path").
+			huh.NewSelect[int]().
+				Key("script").
 				Options(options...).
 				Title("Choose a Script"),
+			huh.NewInput().
+				Title("Game name").
+				Description("Names the save slot; defaults to the script and date").
+				Value(&m.gameName),
 		),
 	)
 }
 
-func (m *SetupModel) loadScript() {
-	path := m.form.GetString("script_path")
-	data, _ := os.ReadFile(path)
-	var script model.Script
-	json.Unmarshal(data, &script)
+// loadScript validates the selected script. The game's script is only
+// replaced when it is usable.
+func (m *SetupModel) loadScript() error {
+	source := m.scripts[m.form.GetInt("script")]
+	if source.Err != nil {
+		return source.Err
+	}
+	if err := source.Script.Validate(); err != nil {
+		return err
+	}
+	m.game.Script = source.Script
+	m.script = source.Script
+	m.setupChoices = nil
+	for _, r := range source.Script.Roles {
+		if r.SetupReversible && r.SetupModifier != nil {
+			m.setupChoices = append(m.setupChoices, setupChoice{role: r})
+		}
+	}
+	return nil
+}
+
+// buildSetupChoiceForm asks which way each reversible setup modifier goes,
+// should its character end up in play.
+func (m *SetupModel) buildSetupChoiceForm() *huh.Form {
+	fields := make([]huh.Field, len(m.setupChoices))
+	for i, c := range m.setupChoices {
+		modifier := *c.role.SetupModifier
+		fields[i] = huh.NewSelect[bool]().
+			Value(&m.setupChoices[i].reversed).
+			Options(
+				huh.NewOption(modifier.Delta(), false),
+				huh.NewOption(modifier.Negate().Delta(), true),
+			).
+			Title(fmt.Sprintf("If the %s is in play, how does it change setup?", c.role.Name)).
+			Description(c.role.Ability)
+	}
+	return huh.NewForm(
+		huh.NewGroup(fields...),
+	)
+}
+
+// applySetupChoices sets the game's script to the loaded one with the chosen
+// setup modifiers reversed.
+func (m *SetupModel) applySetupChoices() error {
+	var reversed []string
+	for _, c := range m.setupChoices {
+		if c.reversed {
+			reversed = append(reversed, c.role.Name)
+		}
+	}
+	script, err := m.script.ReverseSetup(reversed...)
+	if err != nil {
+		return err
+	}
+	m.err = nil
 	m.game.Script = script
+	return nil
+}
+
+// chooseRoles deals a random bag or asks for the storyteller's picks,
+// depending on the role mode.
+func (m *SetupModel) chooseRoles() tea.Cmd {
+	if !m.manualBag {
+		return m.dealRoles()
+	}
+	m.form = m.buildBagForm()
+	m.step = StepBagSelect
+	return m.form.Init()
 }
 
 func (m *SetupModel) buildPlayerCountForm() *huh.Form {
@@ -118,8 +302,8 @@ func (m *SetupModel) buildPlayerCountForm() *huh.Form {
 					if err != nil {
 						return fmt.Errorf("must be a number")
 					}
-					if i < 5 || i > 15 {
-						return fmt.Errorf("must be between 5 and 15")
+					if i < model.MinPlayers || i > model.MaxPlayers {
+						return fmt.Errorf("must be between %d and %d", model.MinPlayers, model.MaxPlayers)
 					}
 					return nil
 				}),
@@ -127,6 +311,232 @@ func (m *SetupModel) buildPlayerCountForm() *huh.Form {
 	)
 }
 
+// dealRoles deals a random bag and asks the storyteller to confirm it. If the
+// script cannot fill the bag, the player count is asked again.
+func (m *SetupModel) dealRoles() tea.Cmd {
+	if err := m.assignRandomRoles(); err != nil {
+		m.err = err
+		m.form = m.buildPlayerCountForm()
+		m.step = StepPlayerCount
+		return m.form.Init()
+	}
+	m.err = nil
+	return m.confirmRoles()
+}
+
+func (m *SetupModel) confirmRoles() tea.Cmd {
+	m.form = m.buildConfirmRolesForm()
+	m.step = StepConfirmRoles
+	return m.form.Init()
+}
+
+func (m *SetupModel) chooseRoleMode() tea.Cmd {
+	m.form = m.buildRoleModeForm()
+	m.step = StepRoleMode
+	return m.form.Init()
+}
+
+func (m *SetupModel) buildRoleModeForm() *huh.Form {
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Key("role_mode").
+				Options(
+					huh.NewOption("Deal a random bag", "random"),
+					huh.NewOption("Choose the bag myself", "manual"),
+				).
+				Title("How should characters be chosen?"),
+		),
+	)
+}
+
+// bagRoles resolves the picked role names against the script.
+func (m *SetupModel) bagRoles(names []string) []model.Role {
+	roles := make([]model.Role, 0, len(names))
+	for _, name := range names {
+		if r, ok := m.game.Script.FindRole(name); ok {
+			roles = append(roles, r)
+		}
+	}
+	return roles
+}
+
+func (m *SetupModel) buildBagForm() *huh.Form {
+	options := make([]huh.Option[string], 0, len(m.game.Script.Roles))
+	for _, r := range m.game.Script.Roles {
+		if r.Type == model.Traveler {
+			continue
+		}
+		label := fmt.Sprintf("%s (%s)", r.Name, r.Type)
+		options = append(options, huh.NewOption(label, r.Name).Selected(slices.Contains(m.bag, r.Name)))
+	}
+
+	count := len(m.game.Players)
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewMultiSelect[string]().
+				Value(&m.bag).
+				Options(options...).
+				Title(fmt.Sprintf("Choose %d characters", count)).
+				DescriptionFunc(func() string {
+					bag := m.bagRoles(m.bag)
+					want, _ := model.ValidateBag(bag, count)
+					return fmt.Sprintf("Picked: %s\nNeeded: %s", model.CountBag(bag), want)
+				}, &m.bag).
+				Validate(func(names []string) error {
+					_, err := model.ValidateBag(m.bagRoles(names), count)
+					return err
+				}),
+		),
+	)
+}
+
+func (m *SetupModel) buildDealModeForm() *huh.Form {
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Key("deal_mode").
+				Options(
+					huh.NewOption("Deal them randomly", "random"),
+					huh.NewOption("Assign each one to a seat", "seats"),
+				).
+				Title("How should the bag be handed out?"),
+		),
+	)
+}
+
+// checkSeatRoles makes sure every character in the bag went to exactly one seat.
+func (m *SetupModel) checkSeatRoles() error {
+	seen := make(map[string]string)
+	for i, name := range m.seatRoles {
+		if other, ok := seen[name]; ok {
+			return fmt.Errorf("%s is assigned to both %s and %s", name, other, m.game.Players[i].Name)
+		}
+		seen[name] = m.game.Players[i].Name
+	}
+	return nil
+}
+
+// dealBag shuffles the picked bag out to the seats.
+func (m *SetupModel) dealBag() {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	roles := m.bagRoles(m.bag)
+	r.Shuffle(len(roles), func(i, j int) { roles[i], roles[j] = roles[j], roles[i] })
+	for i, p := range m.game.Players {
+		p.Role = roles[i]
+	}
+	m.distribution = model.CountBag(roles)
+}
+
+func (m *SetupModel) buildSeatAssignForm() *huh.Form {
+	options := make([]huh.Option[string], len(m.bag))
+	for i, name := range m.bag {
+		options[i] = huh.NewOption(name, name)
+	}
+
+	m.distribution = model.CountBag(m.bagRoles(m.bag))
+
+	fields := make([]huh.Field, len(m.game.Players))
+	for i, p := range m.game.Players {
+		seat := i
+		fields[i] = huh.NewSelect[string]().
+			Value(&m.seatRoles[seat]).
+			Options(options...).
+			Title(fmt.Sprintf("%d. %s", p.ID, p.Name)).
+			Validate(func(name string) error {
+				for j, other := range m.seatRoles {
+					if j != seat && other == name {
+						return fmt.Errorf("%s is already assigned to %s", name, m.game.Players[j].Name)
+					}
+				}
+				return nil
+			})
+	}
+
+	return huh.NewForm(
+		huh.NewGroup(fields...),
+	)
+}
+
+func (m *SetupModel) buildConfirmRolesForm() *huh.Form {
+	base := model.BaseDistribution(len(m.game.Players))
+	m.confirmed = true // Default to starting the game
+	negative := "Re-deal"
+	if m.manualBag {
+		negative = "Change"
+	}
+
+	desc := strings.Builder{}
+	desc.WriteString(fmt.Sprintf("Base:     %s\n", base))
+	desc.WriteString(fmt.Sprintf("In play:  %s\n", m.distribution))
+	for _, p := range m.game.Players {
+		if p.Role.SetupModifier != nil {
+			desc.WriteString(fmt.Sprintf("  %s modifies setup: %s\n", p.Role.Name, p.Role.SetupModifier.Delta()))
+		}
+	}
+	desc.WriteString("\n")
+	for _, p := range m.game.Players {
+		desc.WriteString(fmt.Sprintf("%-12s %s\n", p.Name, styleRole(p.Role.Name, p.Role.Type)))
+	}
+
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Key("confirm_roles").
+				Value(&m.confirmed).
+				Title("Start with these characters?").
+				Description(desc.String()).
+				Affirmative("Start").
+				Negative(negative),
+		),
+	)
+}
+
+// afterRolesDealt moves to the first step that depends on the dealt roles.
+func (m *SetupModel) afterRolesDealt() tea.Cmd {
+	if m.drunkIndex() != -1 {
+		if len(m.game.NotInPlayRoles(model.Townsfolk)) == 0 {
+			// Nothing to offer in the Drunk step, so the roles have to change
+			m.err = fmt.Errorf("every Townsfolk on the script is in play, so the Drunk has none to believe they are; choose the roles again")
+			return m.chooseRoleMode()
+		}
+		m.form = m.buildDrunkForm()
+		m.step = StepDrunk
+		return m.form.Init()
+	}
+	m.finished = true
+	return nil
+}
+
+func (m *SetupModel) drunkIndex() int {
+	for i, p := range m.game.Players {
+		if p.Role.Name == "Drunk" {
+			return i
+		}
+	}
+	return -1
+}
+
+func (m *SetupModel) buildDrunkForm() *huh.Form {
+	drunk := m.game.Players[m.drunkIndex()]
+
+	// The Drunk must believe they are a Townsfolk who is not in play
+	roles := m.game.NotInPlayRoles(model.Townsfolk)
+	options := make([]huh.Option[string], len(roles))
+	for i, r := range roles {
+		options[i] = huh.NewOption(r.Name, r.Name)
+	}
+
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Key("drunk_role").
+				Options(options...).
+				Title(fmt.Sprintf("%s is the Drunk. Which Townsfolk do they think they are?", drunk.Name)),
+		),
+	)
+}
+
 func (m *SetupModel) buildPlayerNamesForm(count int) *huh.Form {
 	fields := make([]huh.Field, count)
 	for i := 0; i < count; i++ {
@@ -142,3 +552,20 @@ func (m *SetupModel) buildPlayerNamesForm(count int) *huh.Form {
 		huh.NewGroup(fields...),
 	)
 }
+
+func (m *SetupModel) assignRandomRoles() error {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	roles, dist, err := model.DealRoles(m.game.Script, len(m.game.Players), r)
+	if err != nil {
+		return err
+	}
+	m.distribution = dist
+
+	for i, p := range m.game.Players {
+		if i < len(roles) {
+			p.Role = roles[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package tui
+
+import (
+	"clocktower/model"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// Town square: the players drawn around an ellipse in seat order, clockwise
+// from the top, as an alternative to the table. Each seat shows the player,
+// their character, a shroud when dead, their ghost vote, status effects,
+// selection marks and reminder tokens.
+
+const (
+	seatWidth  = 18
+	seatHeight = 5
+	// Lines the surrounding views need above and below the town square
+	townSquareChrome = 14
+)
+
+var (
+	styleShroud   = lipgloss.NewStyle().Foreground(ColorText).Background(lipgloss.Color("#3A3A3A"))
+	styleDeadSeat = lipgloss.NewStyle().Foreground(ColorSubtext)
+	styleReminder = lipgloss.NewStyle().Foreground(ColorGold)
+)
+
+// townSquareSize fits the ellipse to the terminal, with a fallback until the
+// first WindowSizeMsg arrives.
+func (m *GrimoireModel) townSquareSize() (width, height int) {
+	width, height = 100, 26
+	if m.width > 0 {
+		width = m.width
+	}
+	if m.height > 0 {
+		height = m.height - townSquareChrome
+	}
+	return width, height
+}
+
+// renderTownSquare draws the seats around an ellipse. It falls back to the
+// table when the terminal is too small to fit the circle.
+func (m *GrimoireModel) renderTownSquare(activeCursor int, marks map[int]string) string {
+	players := m.game.Players
+	width, height := m.townSquareSize()
+	if width < seatWidth*4 || height < seatHeight*3 {
+		return lipgloss.NewStyle().Foreground(ColorSubtext).Render("(Terminal too small for the town square)") + "\n" +
+			renderPlayerTable(players, activeCursor, marks)
+	}
+
+	c := newCanvas(width, height)
+	cx, cy := float64(width)/2, float64(height)/2
+	rx := float64(width-seatWidth)/2 - 1
+	ry := float64(height-seatHeight) / 2
+
+	for i, p := range players {
+		if p == nil {
+			continue
+		}
+		// Seat 1 at the top, then clockwise
+		angle := -math.Pi/2 + 2*math.Pi*float64(i)/float64(len(players))
+		x := int(math.Round(cx + rx*math.Cos(angle) - seatWidth/2))
+		y := int(math.Round(cy + ry*math.Sin(angle) - seatHeight/2))
+		m.drawSeat(c, x, y, p, i == activeCursor, marks[i])
+	}
+
+	good, evil := m.game.GetAliveCounts()
+	center := []string{
+		fmt.Sprintf("%s %d", m.game.Phase, m.game.Turn),
+		fmt.Sprintf("Alive: %d good, %d evil", good, evil),
+		"Seats run clockwise ↻",
+	}
+	for i, line := range center {
+		x := int(cx) - runewidth.StringWidth(line)/2
+		c.put(x, int(cy)-1+i, line, lipgloss.NewStyle().Foreground(ColorSubtext))
+	}
+	return c.String()
+}
+
+func (m *GrimoireModel) drawSeat(c *canvas, x, y int, p *model.Player, selected bool, mark string) {
+	nameStyle := lipgloss.NewStyle().Foreground(ColorText).Bold(true)
+	cursor := "  "
+	if selected {
+		nameStyle = nameStyle.Foreground(ColorSecondary)
+		cursor = "> "
+	}
+	if !p.IsAlive && !selected {
+		nameStyle = styleDeadSeat
+	}
+	c.put(x, y, truncate(fmt.Sprintf("%s%d %s", cursor, p.ID, p.Name), seatWidth), nameStyle)
+
+	token := roleLabel(p)
+	if p.BecameDemon() {
+		token += " ★"
+	}
+	c.put(x+2, y+1, truncate(token, seatWidth-2), roleStyle(p.Role.Type))
+
+	if !p.IsAlive {
+		c.put(x+2, y+2, " DEAD ", styleShroud)
+		if !p.UsedGhostVote {
+			c.put(x+9, y+2, "vote", lipgloss.NewStyle().Foreground(ColorSuccess))
+		}
+	}
+
+	var effects []string
+	if p.IsPoisoned() {
+		effects = append(effects, "Poisoned")
+	}
+	if p.IsDrunk() {
+		effects = append(effects, "Drunk")
+	}
+	if p.IsProtected() {
+		effects = append(effects, "Safe")
+	}
+	if p.IsRedHerring {
+		effects = append(effects, "Herring")
+	}
+	if p.RegistrationOverride != "" {
+		effects = append(effects, "Reg:"+p.RegistrationOverride)
+	}
+	if mark != "" {
+		effects = append([]string{mark}, effects...)
+	}
+	line := 2
+	if !p.IsAlive {
+		line = 3
+	}
+	if len(effects) > 0 {
+		c.put(x+2, y+line, truncate(strings.Join(effects, " "), seatWidth-2), lipgloss.NewStyle().Foreground(ColorSecondary))
+		line++
+	}
+	if len(p.Reminders) > 0 && line < seatHeight {
+		c.put(x+2, y+line, truncate(formatReminders(p), seatWidth-2), styleReminder)
+	}
+}
+
+func truncate(s string, width int) string {
+	return runewidth.Truncate(s, width, "…")
+}
+
+// canvas is a grid of terminal cells that styled text can be placed on
+// anywhere, then rendered row by row.
+type canvas struct {
+	width, height int
+	cells         [][]canvasCell
+	styles        []lipgloss.Style
+}
+
+type canvasCell struct {
+	r     rune
+	style int  // Index into styles, -1 for none
+	wide  bool // Second column of a double-width rune
+}
+
+func newCanvas(width, height int) *canvas {
+	c := &canvas{width: width, height: height, cells: make([][]canvasCell, height)}
+	for y := range c.cells {
+		c.cells[y] = make([]canvasCell, width)
+		for x := range c.cells[y] {
+			c.cells[y][x] = canvasCell{r: ' ', style: -1}
+		}
+	}
+	return c
+}
+
+// put writes text starting at x, y. Anything outside the canvas is clipped.
+func (c *canvas) put(x, y int, text string, style lipgloss.Style) {
+	if y < 0 || y >= c.height {
+		return
+	}
+	c.styles = append(c.styles, style)
+	idx := len(c.styles) - 1
+	for _, r := range text {
+		w := runewidth.RuneWidth(r)
+		if w == 0 {
+			continue
+		}
+		if x >= 0 && x+w <= c.width {
+			c.cells[y][x] = canvasCell{r: r, style: idx}
+			if w == 2 {
+				c.cells[y][x+1] = canvasCell{style: idx, wide: true}
+			}
+		}
+		x += w
+	}
+}
+
+func (c *canvas) String() string {
+	var s strings.Builder
+	for _, row := range c.cells {
+		var line strings.Builder
+		run := strings.Builder{}
+		runStyle := -1
+		flush := func() {
+			if runStyle == -1 {
+				line.WriteString(run.String())
+			} else {
+				line.WriteString(c.styles[runStyle].Render(run.String()))
+			}
+			run.Reset()
+		}
+		for _, cell := range row {
+			if cell.wide {
+				continue
+			}
+			if cell.style != runStyle {
+				flush()
+				runStyle = cell.style
+			}
+			run.WriteRune(cell.r)
+		}
+		flush()
+		s.WriteString(strings.TrimRight(line.String(), " ") + "\n")
+	}
+	return s.String()
+}